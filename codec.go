@@ -0,0 +1,126 @@
+package seqs
+
+import (
+	"bufio"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// Codec produces encoders and decoders for streams of values of type T.
+// See [GobCodec] for an implementation using [encoding/gob].
+type Codec[T any] interface {
+	NewEncoder(io.Writer) Encoder[T]
+	NewDecoder(io.Reader) Decoder[T]
+}
+
+// Encoder writes a stream of values of type T.
+type Encoder[T any] interface {
+	Encode(T) error
+}
+
+// Decoder reads a stream of values of type T.
+// At the end of the stream, Decode returns [io.EOF].
+type Decoder[T any] interface {
+	Decode(*T) error
+}
+
+// GobCodec is a [Codec] that uses [encoding/gob].
+type GobCodec[T any] struct{}
+
+// NewEncoder implements [Codec.NewEncoder].
+func (GobCodec[T]) NewEncoder(w io.Writer) Encoder[T] {
+	return gobEncoder[T]{enc: gob.NewEncoder(w)}
+}
+
+// NewDecoder implements [Codec.NewDecoder].
+func (GobCodec[T]) NewDecoder(r io.Reader) Decoder[T] {
+	return gobDecoder[T]{dec: gob.NewDecoder(r)}
+}
+
+type gobEncoder[T any] struct {
+	enc *gob.Encoder
+}
+
+func (e gobEncoder[T]) Encode(val T) error {
+	return e.enc.Encode(val)
+}
+
+type gobDecoder[T any] struct {
+	dec *gob.Decoder
+}
+
+func (d gobDecoder[T]) Decode(val *T) error {
+	return d.dec.Decode(val)
+}
+
+// EncodeStream writes the values in inp to w using the given codec,
+// or [GobCodec] if codec is nil.
+// The values can be read back with [DecodeStream],
+// for instance to checkpoint the state of a pipeline
+// and replay it later.
+//
+// Output is buffered and flushed when inp is exhausted.
+//
+// To produce the encoded stream in the background,
+// run EncodeStream in a goroutine with an [io.Pipe]:
+//
+//	pr, pw := io.Pipe()
+//	go func() { pw.CloseWithError(seqs.EncodeStream(pw, inp, nil)) }()
+//	vals, errptr := seqs.DecodeStream[T](pr, nil)
+func EncodeStream[T any](w io.Writer, inp iter.Seq[T], codec Codec[T]) error {
+	if codec == nil {
+		codec = GobCodec[T]{}
+	}
+
+	var (
+		bw  = bufio.NewWriter(w)
+		enc = codec.NewEncoder(bw)
+		n   int
+	)
+
+	for val := range inp {
+		if err := enc.Encode(val); err != nil {
+			return fmt.Errorf("encoding value %d: %w", n, err)
+		}
+		n++
+	}
+
+	return bw.Flush()
+}
+
+// DecodeStream produces an iterator over the values in r,
+// as written by [EncodeStream] with the given codec,
+// or [GobCodec] if codec is nil.
+//
+// The caller can dereference the returned error pointer to check for errors
+// but only after iteration is done.
+func DecodeStream[T any](r io.Reader, codec Codec[T]) (iter.Seq[T], *error) {
+	if codec == nil {
+		codec = GobCodec[T]{}
+	}
+
+	var err error
+
+	f := func(yield func(T) bool) {
+		dec := codec.NewDecoder(r)
+		for n := 0; ; n++ {
+			var val T
+			if err = dec.Decode(&val); errors.Is(err, io.EOF) {
+				err = nil
+				return
+			}
+			if err != nil {
+				err = fmt.Errorf("decoding value %d: %w", n, err)
+				return
+			}
+			if !yield(val) {
+				return
+			}
+		}
+	}
+
+	return f, &err
+}
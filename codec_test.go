@@ -0,0 +1,112 @@
+package seqs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// jsonCodec is a [Codec] for testing with a non-default codec.
+type jsonCodec[T any] struct{}
+
+func (jsonCodec[T]) NewEncoder(w io.Writer) Encoder[T] { return jsonEncoder[T]{json.NewEncoder(w)} }
+func (jsonCodec[T]) NewDecoder(r io.Reader) Decoder[T] { return jsonDecoder[T]{json.NewDecoder(r)} }
+
+type jsonEncoder[T any] struct{ enc *json.Encoder }
+
+func (e jsonEncoder[T]) Encode(val T) error { return e.enc.Encode(val) }
+
+type jsonDecoder[T any] struct{ dec *json.Decoder }
+
+func (d jsonDecoder[T]) Decode(val *T) error { return d.dec.Decode(val) }
+
+type streamTestRec struct {
+	Name string
+	Tags []string
+	N    int
+}
+
+func TestEncodeDecodeStream(t *testing.T) {
+	recs := []streamTestRec{
+		{Name: "a", Tags: []string{"x", "y"}, N: 1},
+		{Name: "b", N: 2},
+		{Name: "c", Tags: []string{"z"}, N: 3},
+	}
+
+	codecs := map[string]Codec[streamTestRec]{
+		"default": nil,
+		"gob":     GobCodec[streamTestRec]{},
+		"json":    jsonCodec[streamTestRec]{},
+	}
+
+	for name, codec := range codecs {
+		t.Run(name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if err := EncodeStream(buf, slices.Values(recs), codec); err != nil {
+				t.Fatal(err)
+			}
+
+			decoded, errptr := DecodeStream(buf, codec)
+			got := slices.Collect(decoded)
+			if err := *errptr; err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(recs, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeStreamTruncated(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if err := EncodeStream(buf, slices.Values([]string{"hello", "world"}), nil); err != nil {
+		t.Fatal(err)
+	}
+	input := buf.Bytes()[:buf.Len()-2]
+
+	decoded, errptr := DecodeStream[string](bytes.NewReader(input), nil)
+	if got, want := slices.Collect(decoded), []string{"hello"}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if err := *errptr; !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("got error %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
+
+func TestDecodeStreamGo(t *testing.T) {
+	// Encode in one goroutine and decode in another,
+	// with the decoded values delivered by Go.
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(EncodeStream(pw, Limit(Ints(0, 1), 1000), nil))
+	}()
+
+	vals, errptr := Go(func(ch chan<- int) error {
+		decoded, errptr := DecodeStream[int](pr, nil)
+		for val := range decoded {
+			ch <- val
+		}
+		return *errptr
+	})
+
+	var n int
+	for val := range vals {
+		if val != n {
+			t.Fatalf("got %d, want %d", val, n)
+		}
+		n++
+	}
+	if err := *errptr; err != nil {
+		t.Fatal(err)
+	}
+	if n != 1000 {
+		t.Errorf("got %d values, want 1000", n)
+	}
+}
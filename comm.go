@@ -2,6 +2,7 @@ package seqs
 
 import (
 	"cmp"
+	"fmt"
 	"iter"
 )
 
@@ -95,3 +96,139 @@ func CommBothFunc[T any](left, right iter.Seq[T], cmp func(T, T) int) iter.Seq[T
 		}
 	}
 }
+
+// CommColumn tells which of two inputs an element of [Comm] output belongs to.
+// The name comes from the Unix comm command,
+// which prints its output in three columns.
+type CommColumn int
+
+const (
+	InLeft  CommColumn = iota + 1 // The element is in the left input but not the right.
+	InRight                       // The element is in the right input but not the left.
+	InBoth                        // The element is in both inputs.
+)
+
+func (c CommColumn) String() string {
+	switch c {
+	case InLeft:
+		return "left"
+	case InRight:
+		return "right"
+	case InBoth:
+		return "both"
+	default:
+		return fmt.Sprintf("CommColumn(%d)", int(c))
+	}
+}
+
+// Comm takes two ordered sequences and produces a single ordered sequence of their elements,
+// each paired with a [CommColumn] telling whether it is in left only, right only, or both.
+// For example, if left is [1, 2, 3, 4] and right is [2, 4, 6, 8], Comm produces
+// (1, InLeft), (2, InBoth), (3, InLeft), (4, InBoth), (6, InRight), (8, InRight).
+//
+// Unlike calling [CommLeft], [CommRight], and [CommBoth] separately,
+// Comm consumes each input only once.
+//
+// Duplicate elements are treated as in a multiset:
+// each element of left is matched with at most one equal element of right.
+// So if left is [1, 1, 1] and right is [1],
+// Comm produces (1, InBoth), (1, InLeft), (1, InLeft).
+func Comm[T cmp.Ordered](left, right iter.Seq[T]) iter.Seq2[T, CommColumn] {
+	return CommFunc(left, right, cmp.Compare)
+}
+
+// CommFunc is like [Comm], but it uses a custom comparison function.
+// The function cmp must return a negative value if its arguments are properly ordered,
+// a positive value if they are reversed,
+// and zero if they are equal.
+// When an element is in both inputs, the output contains the one from left.
+func CommFunc[T any](left, right iter.Seq[T], cmp func(T, T) int) iter.Seq2[T, CommColumn] {
+	return func(yield func(T, CommColumn) bool) {
+		next, stop := iter.Pull(right)
+		defer stop()
+
+		r, ok := next()
+
+		for l := range left {
+			for ok && cmp(l, r) > 0 {
+				if !yield(r, InRight) {
+					return
+				}
+				r, ok = next()
+			}
+			if ok && cmp(l, r) == 0 {
+				if !yield(l, InBoth) {
+					return
+				}
+				r, ok = next()
+				continue
+			}
+			if !yield(l, InLeft) {
+				return
+			}
+		}
+
+		for ok {
+			if !yield(r, InRight) {
+				return
+			}
+			r, ok = next()
+		}
+	}
+}
+
+// Comm2 is like [Comm] but operates on two sequences of key-value pairs, ordered by key.
+// It produces each key paired with a [Zipped] holding the left and right values.
+// In the Zipped value,
+// Ok1 and Ok2 tell whether the key is in left, right, or both.
+//
+// As with Comm, duplicate keys are treated as in a multiset:
+// each pair in left is matched with at most one pair in right.
+func Comm2[K cmp.Ordered, V1, V2 any](left iter.Seq2[K, V1], right iter.Seq2[K, V2]) iter.Seq2[K, Zipped[V1, V2]] {
+	return CommFunc2(left, right, cmp.Compare)
+}
+
+// CommFunc2 is like [Comm2], but it uses a custom comparison function for keys.
+// The function cmp must return a negative value if its arguments are properly ordered,
+// a positive value if they are reversed,
+// and zero if they are equal.
+// When a key is in both inputs, the output contains the one from left.
+func CommFunc2[K, V1, V2 any](left iter.Seq2[K, V1], right iter.Seq2[K, V2], cmp func(K, K) int) iter.Seq2[K, Zipped[V1, V2]] {
+	return func(yield func(K, Zipped[V1, V2]) bool) {
+		next, stop := iter.Pull2(right)
+		defer stop()
+
+		var (
+			rk, rv, ok = next()
+
+			zero1 V1
+			zero2 V2
+		)
+
+		for lk, lv := range left {
+			for ok && cmp(lk, rk) > 0 {
+				if !yield(rk, Zipped[V1, V2]{V1: zero1, V2: rv, Ok2: true}) {
+					return
+				}
+				rk, rv, ok = next()
+			}
+			if ok && cmp(lk, rk) == 0 {
+				if !yield(lk, Zipped[V1, V2]{V1: lv, Ok1: true, V2: rv, Ok2: true}) {
+					return
+				}
+				rk, rv, ok = next()
+				continue
+			}
+			if !yield(lk, Zipped[V1, V2]{V1: lv, Ok1: true, V2: zero2}) {
+				return
+			}
+		}
+
+		for ok {
+			if !yield(rk, Zipped[V1, V2]{V1: zero1, V2: rv, Ok2: true}) {
+				return
+			}
+			rk, rv, ok = next()
+		}
+	}
+}
@@ -1,7 +1,10 @@
 package seqs
 
 import (
+	"errors"
+	"reflect"
 	"slices"
+	"strings"
 	"testing"
 )
 
@@ -40,3 +43,61 @@ func TestCommBoth(t *testing.T) {
 		t.Errorf("got %v, want %v", got, want)
 	}
 }
+
+func TestComm(t *testing.T) {
+	t.Run("sets", func(t *testing.T) {
+		var (
+			left  = slices.Values([]int{1, 2, 3, 4})
+			right = slices.Values([]int{2, 4, 6, 8})
+			got   = slices.Collect(ToPairs(Comm(left, right)))
+			want  = []Pair[int, CommColumn]{{1, InLeft}, {2, InBoth}, {3, InLeft}, {4, InBoth}, {6, InRight}, {8, InRight}}
+		)
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("multisets", func(t *testing.T) {
+		var (
+			left  = slices.Values([]int{1, 1, 1, 3, 5, 5})
+			right = slices.Values([]int{0, 1, 3, 3, 5, 5, 5})
+			got   = slices.Collect(ToPairs(Comm(left, right)))
+			want  = []Pair[int, CommColumn]{{0, InRight}, {1, InBoth}, {1, InLeft}, {1, InLeft}, {3, InBoth}, {3, InRight}, {5, InBoth}, {5, InBoth}, {5, InRight}}
+		)
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("one_shot", func(t *testing.T) {
+		left, errptr1 := Lines(strings.NewReader("apple\nbanana\ncherry\n"))
+		right, errptr2 := Lines(strings.NewReader("banana\ndate\n"))
+
+		var cols [3][]string
+		for s, col := range Comm(left, right) {
+			cols[col-1] = append(cols[col-1], s)
+		}
+		if err := errors.Join(*errptr1, *errptr2); err != nil {
+			t.Fatal(err)
+		}
+
+		want := [3][]string{{"apple", "cherry"}, {"date"}, {"banana"}}
+		if !reflect.DeepEqual(cols, want) {
+			t.Errorf("got %v, want %v", cols, want)
+		}
+	})
+}
+
+func TestComm2(t *testing.T) {
+	type z = Zipped[string, int]
+
+	var (
+		left  = FromPairs(slices.Values([]Pair[int, string]{{1, "a"}, {2, "b"}, {2, "c"}}))
+		right = FromPairs(slices.Values([]Pair[int, int]{{2, 20}, {3, 30}}))
+		got   = slices.Collect(ToPairs(Comm2(left, right)))
+		want  = []Pair[int, z]{{1, z{"a", true, 0, false}}, {2, z{"b", true, 20, true}}, {2, z{"c", true, 0, false}}, {3, z{"", false, 30, true}}}
+	)
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
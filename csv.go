@@ -0,0 +1,311 @@
+package seqs
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"reflect"
+	"strconv"
+)
+
+// CSV produces an iterator over the records in r,
+// which is parsed as comma-separated values by a [csv.Reader].
+// Each record is a newly allocated slice of fields.
+//
+// All records must have the same number of fields as the first one.
+// A parse error is reported as a [*csv.ParseError],
+// which includes the line number where it occurred.
+//
+// The caller can dereference the returned error pointer to check for errors
+// but only after iteration is done.
+func CSV(r io.Reader) (iter.Seq[[]string], *error) {
+	var err error
+
+	f := func(yield func([]string) bool) {
+		cr := csv.NewReader(r)
+		for {
+			var rec []string
+			rec, err = cr.Read()
+			if errors.Is(err, io.EOF) {
+				err = nil
+				return
+			}
+			if err != nil {
+				return
+			}
+			if !yield(rec) {
+				return
+			}
+		}
+	}
+
+	return f, &err
+}
+
+// CSVStructs produces an iterator over the records in r,
+// which is parsed as comma-separated values by a [csv.Reader],
+// with each record decoded into a value of the struct type T.
+//
+// The first record in r is a header giving the column names.
+// Columns are matched to struct fields according to the rules described for [SQL],
+// including the use of `db` struct tags.
+//
+// A field is decoded from its column's text as follows.
+// An empty value produces a nil pointer in a pointer-typed field,
+// and a NULL in a field whose pointer implements [sql.Scanner].
+// Otherwise,
+// a field whose pointer implements [sql.Scanner] is scanned from the text as a string;
+// a field whose pointer implements [encoding.TextUnmarshaler]
+// (such as [time.Time], which expects RFC 3339 format)
+// is unmarshaled from the text;
+// and fields of string, []byte, boolean, and numeric types are parsed with the [strconv] package.
+//
+// A parse or decoding error is reported as a [*csv.ParseError],
+// which includes the line number where it occurred.
+//
+// The caller can dereference the returned error pointer to check for errors
+// but only after iteration is done.
+func CSVStructs[T any](r io.Reader) (iter.Seq[T], *error) {
+	var err error
+
+	tt := reflect.TypeFor[T]()
+	if tt.Kind() != reflect.Struct || isSqlScalar(tt) {
+		err = csvKindError{kind: tt.Kind()}
+		return Empty[T], &err
+	}
+
+	f := func(yield func(T) bool) {
+		cr := csv.NewReader(r)
+
+		header, err2 := cr.Read()
+		if errors.Is(err2, io.EOF) {
+			return
+		}
+		if err2 != nil {
+			err = err2
+			return
+		}
+
+		indexes, err2 := sqlPlanFor(tt).columnIndexes(tt, header)
+		if err2 != nil {
+			line, _ := cr.FieldPos(0)
+			err = &csv.ParseError{StartLine: line, Line: line, Column: 1, Err: err2}
+			return
+		}
+
+		for {
+			rec, err2 := cr.Read()
+			if errors.Is(err2, io.EOF) {
+				return
+			}
+			if err2 != nil {
+				err = err2
+				return
+			}
+
+			rowval := reflect.New(tt).Elem()
+			for i, index := range indexes {
+				fieldptr := reflect.ValueOf(sqlFieldAddr(rowval, index))
+				if err2 := csvDecodeField(fieldptr.Elem(), rec[i]); err2 != nil {
+					line, col := cr.FieldPos(i)
+					err = &csv.ParseError{StartLine: line, Line: line, Column: col, Err: fmt.Errorf("column %s: %w", header[i], err2)}
+					return
+				}
+			}
+
+			if !yield(rowval.Interface().(T)) {
+				return
+			}
+		}
+	}
+
+	return f, &err
+}
+
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// csvDecodeField sets v, which must be addressable, from the text s.
+func csvDecodeField(v reflect.Value, s string) error {
+	ptr := v.Addr()
+
+	if ptr.Type().Implements(scannerType) {
+		scanner := ptr.Interface().(sql.Scanner)
+		if s == "" {
+			return scanner.Scan(nil)
+		}
+		return scanner.Scan(s)
+	}
+
+	if v.Kind() == reflect.Pointer {
+		if s == "" {
+			v.SetZero()
+			return nil
+		}
+		elem := reflect.New(v.Type().Elem())
+		if err := csvDecodeField(elem.Elem(), s); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	}
+
+	if ptr.Type().Implements(textUnmarshalerType) {
+		return ptr.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+
+	case reflect.Float32, reflect.Float64:
+		x, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(x)
+
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			return fmt.Errorf("cannot decode CSV into %s", v.Type())
+		}
+		v.SetBytes([]byte(s))
+
+	default:
+		return fmt.Errorf("cannot decode CSV into %s", v.Type())
+	}
+
+	return nil
+}
+
+// WriteCSV writes the values in inp to w as comma-separated values,
+// using a [csv.Writer].
+// T must be a struct type.
+//
+// The first record written is a header giving the column names,
+// which are taken from the struct's fields according to the rules described for [SQL],
+// including the use of `db` struct tags.
+// Each value in inp then produces one record.
+//
+// A field is encoded as follows.
+// A nil pointer produces an empty value,
+// and a non-nil pointer is encoded like the value it points to.
+// A field implementing [driver.Valuer],
+// such as [sql.NullString],
+// is encoded like the value it produces,
+// with NULL producing an empty value.
+// A field implementing [encoding.TextMarshaler]
+// (such as [time.Time])
+// is marshaled as text.
+// A []byte field is written as is,
+// and other fields are formatted with [fmt.Sprint].
+// These rules are compatible with the decoding done by [CSVStructs].
+//
+// This can be combined with [SQL] to export the results of a query:
+//
+//	rows, errptr := seqs.SQL[employee](ctx, db, "SELECT * FROM employees")
+//	err := seqs.WriteCSV(w, rows)
+//	err = errors.Join(err, *errptr)
+func WriteCSV[T any](w io.Writer, inp iter.Seq[T]) error {
+	tt := reflect.TypeFor[T]()
+	if tt.Kind() != reflect.Struct || isSqlScalar(tt) {
+		return csvKindError{kind: tt.Kind()}
+	}
+
+	var (
+		fields = sqlPlanFor(tt).columns()
+		rec    = make([]string, len(fields))
+		cw     = csv.NewWriter(w)
+	)
+
+	for i, f := range fields {
+		rec[i] = f.name
+	}
+	if err := cw.Write(rec); err != nil {
+		return fmt.Errorf("writing header: %w", err)
+	}
+
+	var n int
+	for row := range inp {
+		rowval := reflect.ValueOf(row)
+		for i, f := range fields {
+			s, err := csvEncodeField(sqlFieldValue(rowval, f.index))
+			if err != nil {
+				return fmt.Errorf("encoding field %s of record %d: %w", f.name, n, err)
+			}
+			rec[i] = s
+		}
+		if err := cw.Write(rec); err != nil {
+			return fmt.Errorf("writing record %d: %w", n, err)
+		}
+		n++
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func csvEncodeField(val any) (string, error) {
+	rv := reflect.ValueOf(val)
+	if rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return "", nil
+	}
+
+	switch v := val.(type) {
+	case nil:
+		return "", nil
+
+	case driver.Valuer:
+		dv, err := v.Value()
+		if err != nil {
+			return "", err
+		}
+		return csvEncodeField(dv)
+
+	case encoding.TextMarshaler:
+		text, err := v.MarshalText()
+		return string(text), err
+
+	case []byte:
+		return string(v), nil
+	}
+
+	if rv.Kind() == reflect.Pointer {
+		return csvEncodeField(rv.Elem().Interface())
+	}
+
+	return fmt.Sprint(val), nil
+}
+
+type csvKindError struct {
+	kind reflect.Kind
+}
+
+func (e csvKindError) Error() string {
+	return fmt.Sprintf("type parameter has %s kind but must be struct", e.kind)
+}
@@ -0,0 +1,235 @@
+package seqs
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCSV(t *testing.T) {
+	const input = "a,b\n1,\"x,y\"\n2,z\n"
+
+	recs, errptr := CSV(strings.NewReader(input))
+	got := slices.Collect(recs)
+	if err := *errptr; err != nil {
+		t.Fatal(err)
+	}
+
+	want := [][]string{{"a", "b"}, {"1", "x,y"}, {"2", "z"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestCSVError(t *testing.T) {
+	const input = "a,b\n1,2\n3\n"
+
+	recs, errptr := CSV(strings.NewReader(input))
+	got := slices.Collect(recs)
+	if len(got) != 2 {
+		t.Errorf("got %d records, want 2", len(got))
+	}
+
+	var perr *csv.ParseError
+	if !errors.As(*errptr, &perr) {
+		t.Fatalf("got error %v, want a *csv.ParseError", *errptr)
+	}
+	if perr.Line != 3 {
+		t.Errorf("got error on line %d, want 3", perr.Line)
+	}
+}
+
+type csvTestRow struct {
+	Name    string         `db:"name"`
+	Age     int            `db:"age"`
+	Score   *float64       `db:"score"`
+	Joined  time.Time      `db:"joined"`
+	Nick    sql.NullString `db:"nick"`
+	Active  bool
+	Ignored string `db:"-"`
+}
+
+func TestCSVStructs(t *testing.T) {
+	const input = `name,AGE,score,joined,nick,active
+alice,30,9.5,2024-01-02T03:04:05Z,al,true
+bob,40,,2023-06-07T00:00:00Z,,false
+`
+
+	rows, errptr := CSVStructs[csvTestRow](strings.NewReader(input))
+	got := slices.Collect(rows)
+	if err := *errptr; err != nil {
+		t.Fatal(err)
+	}
+
+	score := 9.5
+	want := []csvTestRow{{
+		Name:   "alice",
+		Age:    30,
+		Score:  &score,
+		Joined: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Nick:   sql.NullString{String: "al", Valid: true},
+		Active: true,
+	}, {
+		Name:   "bob",
+		Age:    40,
+		Joined: time.Date(2023, 6, 7, 0, 0, 0, 0, time.UTC),
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestCSVStructsErrors(t *testing.T) {
+	t.Run("decode", func(t *testing.T) {
+		const input = "name,age\nalice,30\nbob,forty\n"
+
+		rows, errptr := CSVStructs[csvTestRow](strings.NewReader(input))
+		got := slices.Collect(rows)
+		if len(got) != 1 {
+			t.Errorf("got %d rows, want 1", len(got))
+		}
+
+		var perr *csv.ParseError
+		if !errors.As(*errptr, &perr) {
+			t.Fatalf("got error %v, want a *csv.ParseError", *errptr)
+		}
+		if perr.Line != 3 {
+			t.Errorf("got error on line %d, want 3", perr.Line)
+		}
+		if !strings.Contains(perr.Error(), "column age") {
+			t.Errorf("error %q does not name the column", perr)
+		}
+	})
+
+	t.Run("columns", func(t *testing.T) {
+		const input = "name,bogus\nalice,30\n"
+
+		rows, errptr := CSVStructs[csvTestRow](strings.NewReader(input))
+		if got := slices.Collect(rows); len(got) != 0 {
+			t.Errorf("got %d rows, want 0", len(got))
+		}
+
+		var cerr sqlColumnsError
+		if !errors.As(*errptr, &cerr) {
+			t.Fatalf("got error %v, want a sqlColumnsError", *errptr)
+		}
+	})
+
+	t.Run("unknown header", func(t *testing.T) {
+		// As many columns as fields, but none of them matching.
+		const input = "a,b,c,d,e,f\nalice,30,1.5,2024-01-02T03:04:05Z,al,true\n"
+
+		rows, errptr := CSVStructs[csvTestRow](strings.NewReader(input))
+		if got := slices.Collect(rows); len(got) != 0 {
+			t.Errorf("got %d rows, want 0", len(got))
+		}
+
+		var cerr sqlColumnsError
+		if !errors.As(*errptr, &cerr) {
+			t.Fatalf("got error %v, want a sqlColumnsError", *errptr)
+		}
+	})
+
+	t.Run("duplicate header", func(t *testing.T) {
+		const input = "name,age,Name\nalice,30,bob\n"
+
+		rows, errptr := CSVStructs[csvTestRow](strings.NewReader(input))
+		if got := slices.Collect(rows); len(got) != 0 {
+			t.Errorf("got %d rows, want 0", len(got))
+		}
+
+		var derr sqlDuplicateColumnError
+		if !errors.As(*errptr, &derr) {
+			t.Fatalf("got error %v, want a sqlDuplicateColumnError", *errptr)
+		}
+	})
+
+	t.Run("kind", func(t *testing.T) {
+		_, errptr := CSVStructs[int](strings.NewReader("x\n1\n"))
+
+		var kerr csvKindError
+		if !errors.As(*errptr, &kerr) {
+			t.Fatalf("got error %v, want a csvKindError", *errptr)
+		}
+	})
+}
+
+func TestWriteCSV(t *testing.T) {
+	score := 7.25
+	rows := []csvTestRow{{
+		Name:    "carol",
+		Age:     25,
+		Score:   &score,
+		Joined:  time.Date(2022, 2, 3, 4, 5, 6, 0, time.UTC),
+		Nick:    sql.NullString{String: "c, the great", Valid: true},
+		Active:  true,
+		Ignored: "ignored",
+	}, {
+		Name: "dave",
+		Age:  50,
+	}}
+
+	buf := new(bytes.Buffer)
+	if err := WriteCSV(buf, slices.Values(rows)); err != nil {
+		t.Fatal(err)
+	}
+
+	const want = `name,age,score,joined,nick,Active
+carol,25,7.25,2022-02-03T04:05:06Z,"c, the great",true
+dave,50,,0001-01-01T00:00:00Z,,false
+`
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	// Round trip.
+
+	decoded, errptr := CSVStructs[csvTestRow](buf)
+	got := slices.Collect(decoded)
+	if err := *errptr; err != nil {
+		t.Fatal(err)
+	}
+
+	rows[0].Ignored = ""
+	if !reflect.DeepEqual(got, rows) {
+		t.Errorf("got %+v, want %+v", got, rows)
+	}
+}
+
+func TestSQLToCSV(t *testing.T) {
+	var (
+		ctx = context.Background()
+		db  = newTestDB(t, schema)
+	)
+
+	type employee struct {
+		Name   string `db:"name"`
+		Salary int    `db:"salary"`
+	}
+
+	employees := []employee{{Name: "alice", Salary: 100}, {Name: "bob", Salary: 90}}
+	if _, err := InsertSQL(ctx, db, "employees", slices.Values(employees), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		buf          = new(bytes.Buffer)
+		rows, errptr = SQL[employee](ctx, db, "SELECT name, salary FROM employees ORDER BY name")
+	)
+	err := WriteCSV(buf, rows)
+	if err = errors.Join(err, *errptr); err != nil {
+		t.Fatal(err)
+	}
+
+	const want = "name,salary\nalice,100\nbob,90\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,305 @@
+package seqs
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+)
+
+// EditOp is the kind of an [Edit].
+type EditOp int
+
+const (
+	EditKeep   EditOp = iota // Items are in both sequences.
+	EditDelete               // Items are in the first sequence only.
+	EditInsert               // Items are in the second sequence only.
+)
+
+func (op EditOp) String() string {
+	switch op {
+	case EditKeep:
+		return "keep"
+	case EditDelete:
+		return "delete"
+	case EditInsert:
+		return "insert"
+	default:
+		return fmt.Sprintf("EditOp(%d)", int(op))
+	}
+}
+
+// Edit is one step in an edit script produced by [Diff] or [DiffFunc]:
+// a run of consecutive items that are kept, deleted, or inserted.
+type Edit[T any] struct {
+	Op    EditOp
+	Items []T
+}
+
+// Diff computes a minimal edit script transforming the sequence a into the sequence b.
+// Applying the edits in order,
+// keeping or deleting the items of a
+// and inserting the items of b,
+// produces b.
+// Within a run of changes, deletions come before insertions.
+//
+// Diff uses the Myers diff algorithm,
+// which takes time proportional to (N+M)·D
+// and, in addition to the inputs, memory proportional to D²,
+// for input lengths N and M and edit-script length D.
+// It consumes both input sequences in their entirety and holds them in memory.
+// Beware of infinite input!
+func Diff[T comparable](a, b iter.Seq[T]) []Edit[T] {
+	return DiffFunc(a, b, func(x, y T) bool { return x == y })
+}
+
+// DiffFunc is like [Diff] but uses the function eq to compare elements.
+// The items in the resulting EditKeep edits are from a.
+func DiffFunc[T any](a, b iter.Seq[T], eq func(T, T) bool) []Edit[T] {
+	var (
+		as = slices.Collect(a)
+		bs = slices.Collect(b)
+	)
+
+	var result []Edit[T]
+
+	add := func(op EditOp, item T) {
+		if n := len(result); n > 0 && result[n-1].Op == op {
+			result[n-1].Items = append(result[n-1].Items, item)
+			return
+		}
+		result = append(result, Edit[T]{Op: op, Items: []T{item}})
+	}
+
+	for _, step := range myers(len(as), len(bs), func(i, j int) bool { return eq(as[i], bs[j]) }) {
+		switch step.op {
+		case EditInsert:
+			add(EditInsert, bs[step.j])
+		default:
+			add(step.op, as[step.i])
+		}
+	}
+
+	return result
+}
+
+// myersStep is a single-item step in an edit script.
+// For EditKeep and EditDelete, i is the index of the item in the first sequence.
+// For EditInsert, j is the index of the item in the second sequence.
+type myersStep struct {
+	op   EditOp
+	i, j int
+}
+
+// myers computes a minimal edit script transforming a sequence of length n into one of length m.
+// The function eq tells whether item i of the first sequence equals item j of the second.
+//
+// See "An O(ND) Difference Algorithm and Its Variations" by Eugene W. Myers.
+func myers(n, m int, eq func(i, j int) bool) []myersStep {
+	var (
+		maxD   = n + m
+		offset = maxD + 1
+		v      = make([]int, 2*maxD+3) // v[offset+k] is the furthest x reached on diagonal k
+		trace  [][]int                 // trace[d][k+d+1] is v[offset+k] as of the start of step d, for -d-1 <= k <= d+1
+	)
+
+	// Forward pass: find the length of the shortest edit script,
+	// recording the state at each edit distance d.
+	// Step d reads only diagonals -d-1 through d+1 of v
+	// (and writes only -d through d),
+	// so only that part is saved,
+	// making the trace's total size proportional to D² rather than (N+M)·D.
+	var done bool
+	for d := 0; d <= maxD && !done; d++ {
+		trace = append(trace, slices.Clone(v[offset-d-1:offset+d+2]))
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1] // move down: insertion
+			} else {
+				x = v[offset+k-1] + 1 // move right: deletion
+			}
+			y := x - k
+			for x < n && y < m && eq(x, y) {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				done = true
+				break
+			}
+		}
+	}
+
+	// Backward pass: recover the steps by retracing the path from (n, m) to (0, 0).
+	var (
+		steps []myersStep
+		x, y  = n, m
+	)
+	for d := len(trace) - 1; d >= 0; d-- {
+		var (
+			saved = trace[d]
+			v     = func(k int) int { return saved[k+d+1] }
+			k     = x - y
+		)
+
+		var prevK int
+		if k == -d || (k != d && v(k-1) < v(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			steps = append(steps, myersStep{op: EditKeep, i: x, j: y})
+		}
+		if d == 0 {
+			break
+		}
+		if x == prevX {
+			steps = append(steps, myersStep{op: EditInsert, i: x, j: prevY})
+		} else {
+			steps = append(steps, myersStep{op: EditDelete, i: prevX, j: y})
+		}
+		x, y = prevX, prevY
+	}
+
+	slices.Reverse(steps)
+	return steps
+}
+
+// UnifiedDiff writes the differences between a and b to w
+// in the "unified" format of the diff -u command,
+// with the given number of lines of context around each change.
+// The names aName and bName appear in the header.
+// If a and b are equal, UnifiedDiff writes nothing.
+//
+// The items of a and b are lines of text without their line terminators,
+// as produced by [Lines].
+// For example:
+//
+//	a, _ := seqs.Lines(f1)
+//	b, _ := seqs.Lines(f2)
+//	err := seqs.UnifiedDiff(os.Stdout, "f1", "f2", a, b, 3)
+//
+// UnifiedDiff consumes both input sequences in their entirety and holds them in memory.
+func UnifiedDiff(w io.Writer, aName, bName string, a, b iter.Seq[string], context int) error {
+	var (
+		as = slices.Collect(a)
+		bs = slices.Collect(b)
+	)
+
+	steps := myers(len(as), len(bs), func(i, j int) bool { return as[i] == bs[j] })
+
+	// Each step's position in a and b,
+	// i.e. the number of lines of each that precede it.
+	type line struct {
+		op         EditOp
+		text       string
+		aPos, bPos int
+	}
+
+	var (
+		lines      = make([]line, 0, len(steps))
+		aPos, bPos int
+	)
+	for _, step := range steps {
+		switch step.op {
+		case EditKeep:
+			lines = append(lines, line{op: EditKeep, text: as[step.i], aPos: aPos, bPos: bPos})
+			aPos++
+			bPos++
+		case EditDelete:
+			lines = append(lines, line{op: EditDelete, text: as[step.i], aPos: aPos, bPos: bPos})
+			aPos++
+		case EditInsert:
+			lines = append(lines, line{op: EditInsert, text: bs[step.j], aPos: aPos, bPos: bPos})
+			bPos++
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	wroteHeader := false
+
+	for i := 0; i < len(lines); {
+		// Find the next change.
+		for i < len(lines) && lines[i].op == EditKeep {
+			i++
+		}
+		if i == len(lines) {
+			break
+		}
+
+		// Extend the hunk to include subsequent changes
+		// that are close enough for their context lines to touch.
+		start := max(0, i-context)
+		end := i
+		for {
+			for end < len(lines) && lines[end].op != EditKeep {
+				end++
+			}
+			j := end
+			for j < len(lines) && lines[j].op == EditKeep {
+				j++
+			}
+			if j == len(lines) || j-end > 2*context {
+				break
+			}
+			end = j
+		}
+		end = min(len(lines), end+context)
+
+		if !wroteHeader {
+			fmt.Fprintf(bw, "--- %s\n+++ %s\n", aName, bName)
+			wroteHeader = true
+		}
+
+		var aCount, bCount int
+		for _, l := range lines[start:end] {
+			if l.op != EditInsert {
+				aCount++
+			}
+			if l.op != EditDelete {
+				bCount++
+			}
+		}
+		fmt.Fprintf(bw, "@@ -%s +%s @@\n", unifiedRange(lines[start].aPos, aCount), unifiedRange(lines[start].bPos, bCount))
+
+		for _, l := range lines[start:end] {
+			switch l.op {
+			case EditKeep:
+				bw.WriteByte(' ')
+			case EditDelete:
+				bw.WriteByte('-')
+			case EditInsert:
+				bw.WriteByte('+')
+			}
+			bw.WriteString(l.text)
+			bw.WriteByte('\n')
+		}
+
+		i = end
+	}
+
+	return bw.Flush()
+}
+
+// unifiedRange formats the line range of a hunk in unified diff format.
+// The value pos is the number of lines preceding the hunk.
+func unifiedRange(pos, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", pos)
+	case 1:
+		return fmt.Sprintf("%d", pos+1)
+	default:
+		return fmt.Sprintf("%d,%d", pos+1, count)
+	}
+}
@@ -0,0 +1,229 @@
+package seqs
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want []Edit[rune]
+	}{{
+		a: "", b: "",
+	}, {
+		a: "abc", b: "abc",
+		want: []Edit[rune]{{EditKeep, []rune("abc")}},
+	}, {
+		a: "", b: "abc",
+		want: []Edit[rune]{{EditInsert, []rune("abc")}},
+	}, {
+		a: "abc", b: "",
+		want: []Edit[rune]{{EditDelete, []rune("abc")}},
+	}, {
+		a: "abcabba", b: "cbabac",
+		want: []Edit[rune]{
+			{EditDelete, []rune("ab")},
+			{EditKeep, []rune("c")},
+			{EditInsert, []rune("b")},
+			{EditKeep, []rune("ab")},
+			{EditDelete, []rune("b")},
+			{EditKeep, []rune("a")},
+			{EditInsert, []rune("c")},
+		},
+	}, {
+		a: "kitten", b: "sitting",
+		want: []Edit[rune]{
+			{EditDelete, []rune("k")},
+			{EditInsert, []rune("s")},
+			{EditKeep, []rune("itt")},
+			{EditDelete, []rune("e")},
+			{EditInsert, []rune("i")},
+			{EditKeep, []rune("n")},
+			{EditInsert, []rune("g")},
+		},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.a+"_"+tc.b, func(t *testing.T) {
+			got := Diff(Runes(tc.a), Runes(tc.b))
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestDiffRandom checks that applying the edit script to a produces b,
+// and that the script is no longer than the one implied by the longest common subsequence.
+func TestDiffRandom(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	for n := 0; n < 200; n++ {
+		var (
+			a = make([]int, rng.IntN(20))
+			b = make([]int, rng.IntN(20))
+		)
+		for i := range a {
+			a[i] = rng.IntN(4)
+		}
+		for i := range b {
+			b[i] = rng.IntN(4)
+		}
+
+		var (
+			edits   = Diff(slices.Values(a), slices.Values(b))
+			applied []int
+			changes int
+			ai      int
+		)
+		for _, e := range edits {
+			switch e.Op {
+			case EditKeep:
+				if !slices.Equal(e.Items, a[ai:ai+len(e.Items)]) {
+					t.Fatalf("a=%v b=%v: kept items %v do not match a", a, b, e.Items)
+				}
+				applied = append(applied, e.Items...)
+				ai += len(e.Items)
+			case EditDelete:
+				ai += len(e.Items)
+				changes += len(e.Items)
+			case EditInsert:
+				applied = append(applied, e.Items...)
+				changes += len(e.Items)
+			}
+		}
+		if !slices.Equal(applied, b) {
+			t.Fatalf("a=%v b=%v: applying edits gives %v", a, b, applied)
+		}
+		if want := len(a) + len(b) - 2*lcsLen(a, b); changes != want {
+			t.Fatalf("a=%v b=%v: %d changes, want %d", a, b, changes, want)
+		}
+	}
+}
+
+func lcsLen(a, b []int) int {
+	dp := make([][]int, len(a)+1)
+	for i := range dp {
+		dp[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				dp[i][j] = dp[i+1][j+1] + 1
+			} else {
+				dp[i][j] = max(dp[i+1][j], dp[i][j+1])
+			}
+		}
+	}
+	return dp[0][0]
+}
+
+func TestDiffFunc(t *testing.T) {
+	var (
+		a    = slices.Values([]string{"Apple", "Banana", "Cherry"})
+		b    = slices.Values([]string{"apple", "cherry", "date"})
+		got  = DiffFunc(a, b, strings.EqualFold)
+		want = []Edit[string]{
+			{EditKeep, []string{"Apple"}},
+			{EditDelete, []string{"Banana"}},
+			{EditKeep, []string{"Cherry"}},
+			{EditInsert, []string{"date"}},
+		}
+	)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	const (
+		a = "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n14\n15\n"
+		b = "1\n2\nthree\n4\n5\n6\n7\n8\n10\n11\n12\n13\n14\n15\n16\n"
+	)
+
+	cases := []struct {
+		context int
+		want    string
+	}{{
+		context: 3,
+		want: `--- a
++++ b
+@@ -1,15 +1,15 @@
+ 1
+ 2
+-3
++three
+ 4
+ 5
+ 6
+ 7
+ 8
+-9
+ 10
+ 11
+ 12
+ 13
+ 14
+ 15
++16
+`,
+	}, {
+		context: 1,
+		want: `--- a
++++ b
+@@ -2,3 +2,3 @@
+ 2
+-3
++three
+ 4
+@@ -8,3 +8,2 @@
+ 8
+-9
+ 10
+@@ -15 +14,2 @@
+ 15
++16
+`,
+	}, {
+		context: 0,
+		want: `--- a
++++ b
+@@ -3 +3 @@
+-3
++three
+@@ -9 +8,0 @@
+-9
+@@ -15,0 +15 @@
++16
+`,
+	}}
+
+	for _, tc := range cases {
+		t.Run(strings.Repeat("c", tc.context), func(t *testing.T) {
+			aLines, _ := Lines(strings.NewReader(a))
+			bLines, _ := Lines(strings.NewReader(b))
+
+			buf := new(strings.Builder)
+			if err := UnifiedDiff(buf, "a", "b", aLines, bLines, tc.context); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tc.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tc.want)
+			}
+		})
+	}
+
+	t.Run("equal", func(t *testing.T) {
+		buf := new(strings.Builder)
+		if err := UnifiedDiff(buf, "a", "b", From("x", "y"), From("x", "y"), 3); err != nil {
+			t.Fatal(err)
+		}
+		if buf.Len() != 0 {
+			t.Errorf("got %q, want empty output", buf.String())
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package seqs
+
+import (
+	"errors"
+	"iter"
+	"slices"
+)
+
+// ErrSeq is an iterator paired with the errors that may arise while iterating it.
+// It is an alternative to juggling the separate error pointers
+// returned by functions like [Mapx], [Lines], and [SQL]
+// when chaining several of them together.
+//
+// Create an ErrSeq with [WithErr],
+// add fallible stages to it with [Chain],
+// and check for errors after iteration with [ErrSeq.Err],
+// which reports the errors from all stages together.
+//
+// The zero ErrSeq is an empty sequence with no error.
+type ErrSeq[T any] struct {
+	seq     iter.Seq[T]
+	errptrs []*error
+}
+
+// WithErr creates an [ErrSeq] from an iterator and an error pointer,
+// such as those returned by [Mapx], [Lines], and [SQL].
+// For example:
+//
+//	lines := seqs.WithErr(seqs.Lines(r))
+//
+// The error pointer may be nil, for an infallible sequence.
+func WithErr[T any](seq iter.Seq[T], errptr *error) ErrSeq[T] {
+	s := ErrSeq[T]{seq: seq}
+	if errptr != nil {
+		s.errptrs = []*error{errptr}
+	}
+	return s
+}
+
+// Chain adds a fallible stage to an [ErrSeq].
+// The stage function receives the sequence of s
+// and returns a new sequence and error pointer,
+// as many of the functions in this package do.
+// The resulting ErrSeq reports the errors of s as well as the error of the new stage.
+//
+// For example:
+//
+//	lines := seqs.WithErr(seqs.Lines(r))
+//	ints := seqs.Chain(lines, func(inp iter.Seq[string]) (iter.Seq[int], *error) {
+//		return seqs.Mapx(inp, strconv.Atoi)
+//	})
+//	for n := range ints.Seq() {
+//		...
+//	}
+//	if err := ints.Err(); err != nil {
+//		...
+//	}
+func Chain[T, U any](s ErrSeq[T], stage func(iter.Seq[T]) (iter.Seq[U], *error)) ErrSeq[U] {
+	seq, errptr := stage(s.Seq())
+	errptrs := slices.Clip(s.errptrs)
+	if errptr != nil {
+		errptrs = append(errptrs, errptr)
+	}
+	return ErrSeq[U]{seq: seq, errptrs: errptrs}
+}
+
+// Seq returns the iterator of s.
+func (s ErrSeq[T]) Seq() iter.Seq[T] {
+	if s.seq == nil {
+		return Empty[T]
+	}
+	return s.seq
+}
+
+// Err returns the errors, if any, from all the stages of s,
+// joined with [errors.Join].
+// It is only meaningful after the iterator of s has been consumed.
+func (s ErrSeq[T]) Err() error {
+	errs := make([]error, 0, len(s.errptrs))
+	for _, errptr := range s.errptrs {
+		errs = append(errs, *errptr)
+	}
+	return errors.Join(errs...)
+}
+
+// Collect consumes the iterator of s,
+// returning a slice of its values together with the result of [ErrSeq.Err].
+func (s ErrSeq[T]) Collect() ([]T, error) {
+	vals := slices.Collect(s.Seq())
+	return vals, s.Err()
+}
+
+// Filter returns a copy of s that includes only the values v for which f(v) is true.
+func (s ErrSeq[T]) Filter(f func(T) bool) ErrSeq[T] {
+	return ErrSeq[T]{seq: Filter(s.Seq(), f), errptrs: s.errptrs}
+}
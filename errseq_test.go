@@ -0,0 +1,73 @@
+package seqs
+
+import (
+	"errors"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestErrSeq(t *testing.T) {
+	atoi := func(inp iter.Seq[string]) (iter.Seq[int], *error) {
+		return Mapx(inp, strconv.Atoi)
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		var (
+			lines = WithErr(Lines(strings.NewReader("1\n2\n3\n4\n")))
+			ints  = Chain(lines, atoi)
+			evens = ints.Filter(func(n int) bool { return n%2 == 0 })
+		)
+		got, err := evens.Collect()
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []int{2, 4}
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		var (
+			lines = WithErr(Lines(strings.NewReader("1\nx\n3\n")))
+			ints  = Chain(lines, atoi)
+		)
+		got, err := ints.Collect()
+		var numErr *strconv.NumError
+		if !errors.As(err, &numErr) {
+			t.Errorf("got error %v, want a *strconv.NumError", err)
+		}
+		want := []int{1}
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("joined", func(t *testing.T) {
+		var (
+			err1 = errors.New("one")
+			err2 = errors.New("two")
+			s    = WithErr(From(1, 2, 3), &err1)
+			s2   = Chain(s, func(inp iter.Seq[int]) (iter.Seq[int], *error) {
+				return inp, &err2
+			})
+		)
+		if err := s2.Err(); !errors.Is(err, err1) || !errors.Is(err, err2) {
+			t.Errorf("got %v, want both %v and %v", err, err1, err2)
+		}
+		if err := s.Err(); errors.Is(err, err2) {
+			t.Errorf("earlier stage reports later stage's error %v", err)
+		}
+	})
+
+	t.Run("zero", func(t *testing.T) {
+		var s ErrSeq[int]
+		got, err := s.Collect()
+		if err != nil || len(got) != 0 {
+			t.Errorf("got %v, %v; want empty and no error", got, err)
+		}
+	})
+}
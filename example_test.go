@@ -2,8 +2,10 @@ package seqs_test
 
 import (
 	"fmt"
+	"iter"
 	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/bobg/seqs"
 )
@@ -64,6 +66,30 @@ func ExampleBytes() {
 	// 140
 }
 
+func ExampleChain() {
+	r := strings.NewReader("1\n2\n3\n")
+
+	var (
+		lines = seqs.WithErr(seqs.Lines(r))
+		ints  = seqs.Chain(lines, func(inp iter.Seq[string]) (iter.Seq[int], *error) {
+			return seqs.Mapx(inp, strconv.Atoi)
+		})
+		sums = seqs.Chain(ints, func(inp iter.Seq[int]) (iter.Seq[int], *error) {
+			return seqs.Accumx(inp, 0, func(a, b int) (int, error) { return a + b, nil })
+		})
+	)
+	for sum := range sums.Seq() {
+		fmt.Println(sum)
+	}
+	if err := sums.Err(); err != nil {
+		panic(err)
+	}
+	// Output:
+	// 1
+	// 3
+	// 6
+}
+
 func ExampleCheckEmpty() {
 	var (
 		ints  = seqs.Ints(1, 1) // All integers starting at 1
@@ -83,6 +109,21 @@ func ExampleCheckEmpty() {
 	// empty is empty: true
 }
 
+func ExampleChunkBy() {
+	var (
+		words  = slices.Values([]string{"apple", "avocado", "banana", "blueberry", "cherry", "apricot"})
+		chunks = seqs.ChunkBy(words, func(s string) byte { return s[0] })
+	)
+	for initial, run := range chunks {
+		fmt.Println(string(initial), run)
+	}
+	// Output:
+	// a [apple avocado]
+	// b [banana blueberry]
+	// c [cherry]
+	// a [apricot]
+}
+
 func ExampleDup() {
 	var (
 		ints       = seqs.Ints(1, 1)      // All integers starting at 1
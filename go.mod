@@ -7,4 +7,4 @@ require (
 	github.com/mattn/go-sqlite3 v1.14.22
 )
 
-require github.com/bobg/go-generics/v3 v3.7.0 // indirect
+require github.com/bobg/go-generics/v3 v3.7.0
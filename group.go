@@ -0,0 +1,78 @@
+package seqs
+
+import "iter"
+
+// ChunkBy groups consecutive elements of inp that have the same key,
+// as computed by the function key.
+// It produces an iterator over pairs of key and run of elements.
+//
+// For example, if key returns the length of a string,
+// the input ["a", "b", "cc", "dd", "e"] produces
+// (1, ["a", "b"]), (2, ["cc", "dd"]), (1, ["e"]).
+//
+// Each run is a newly allocated slice that the caller may retain.
+// Only consecutive elements are grouped;
+// see [GroupBy] for grouping all the elements of a sequence by key.
+func ChunkBy[T any, K comparable](inp iter.Seq[T], key func(T) K) iter.Seq2[K, []T] {
+	return func(yield func(K, []T) bool) {
+		var (
+			run    []T
+			runKey K
+		)
+
+		for val := range inp {
+			k := key(val)
+			if len(run) > 0 && k != runKey {
+				if !yield(runKey, run) {
+					return
+				}
+				run = nil
+			}
+			runKey = k
+			run = append(run, val)
+		}
+
+		if len(run) > 0 {
+			yield(runKey, run)
+		}
+	}
+}
+
+// GroupBy groups the elements of inp by the key that the function key computes for each one.
+// It returns a map from each key to the elements having that key,
+// in the order they appear in inp.
+//
+// GroupBy consumes the entire input sequence.
+// Beware of infinite input!
+func GroupBy[T any, K comparable](inp iter.Seq[T], key func(T) K) map[K][]T {
+	result := make(map[K][]T)
+	for val := range inp {
+		k := key(val)
+		result[k] = append(result[k], val)
+	}
+	return result
+}
+
+// GroupByReduce groups the elements of inp by the key that the function key computes for each one,
+// combining the elements of each group with f instead of collecting them.
+// It returns a map from each key to the combined value for that key.
+//
+// The combined value for each key begins as init.
+// Then for each value v with that key,
+// it is updated to be f(combined, v),
+// as with [Reduce].
+//
+// GroupByReduce consumes the entire input sequence.
+// Beware of infinite input!
+func GroupByReduce[T any, K comparable, A any](inp iter.Seq[T], key func(T) K, init A, f func(A, T) A) map[K]A {
+	result := make(map[K]A)
+	for val := range inp {
+		k := key(val)
+		acc, ok := result[k]
+		if !ok {
+			acc = init
+		}
+		result[k] = f(acc, val)
+	}
+	return result
+}
@@ -0,0 +1,58 @@
+package seqs
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestChunkBy(t *testing.T) {
+	var (
+		inp    = slices.Values([]string{"a", "b", "cc", "dd", "e", "fff"})
+		chunks = ChunkBy(inp, func(s string) int { return len(s) })
+		got    = slices.Collect(ToPairs(chunks))
+		want   = []Pair[int, []string]{
+			{1, []string{"a", "b"}},
+			{2, []string{"cc", "dd"}},
+			{1, []string{"e"}},
+			{3, []string{"fff"}},
+		}
+	)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	got = slices.Collect(ToPairs(ChunkBy(Empty[string], func(s string) int { return len(s) })))
+	if len(got) != 0 {
+		t.Errorf("got %v, want empty", got)
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	var (
+		inp  = slices.Values([]string{"a", "b", "cc", "dd", "e", "fff"})
+		got  = GroupBy(inp, func(s string) int { return len(s) })
+		want = map[int][]string{
+			1: {"a", "b", "e"},
+			2: {"cc", "dd"},
+			3: {"fff"},
+		}
+	)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestGroupByReduce(t *testing.T) {
+	var (
+		inp  = Limit(Ints(1, 1), 10)
+		got  = GroupByReduce(inp, func(n int) bool { return n%2 == 0 }, 100, func(acc, n int) int { return acc + n })
+		want = map[bool]int{
+			false: 125, // 100 + 1 + 3 + 5 + 7 + 9
+			true:  130, // 100 + 2 + 4 + 6 + 8 + 10
+		}
+	)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
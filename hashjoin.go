@@ -0,0 +1,121 @@
+package seqs
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// ErrHashJoinLimit is the error reported by [HashJoin], [SemiJoin], and [AntiJoin]
+// when the right-hand input contains more pairs than the given limit.
+var ErrHashJoinLimit = errors.New("hash join limit exceeded")
+
+// HashJoin joins two sequences of key-value pairs, which need not be ordered.
+// For each pair in left whose key also appears in right,
+// it produces the key together with a [Zipped] holding the left and right values,
+// with Ok1 and Ok2 both true.
+// If a key appears more than once in right,
+// each left pair with that key is combined with every one of the right values.
+//
+// HashJoin first consumes all of right,
+// building a map from keys to values,
+// then consumes left, looking up each key in the map.
+// So right should be the smaller of the two inputs,
+// and left may be arbitrarily large.
+// The output is in the order of left.
+//
+// If limit is positive and right contains more than limit pairs,
+// HashJoin produces nothing
+// and the returned error pointer contains an error wrapping [ErrHashJoinLimit].
+// The caller may dereference the error pointer only after iteration is done.
+//
+// See [InnerJoin] for a streaming alternative when both inputs are ordered by key,
+// and [KeyBy] for producing keyed sequences from plain ones.
+func HashJoin[K comparable, V1, V2 any](left iter.Seq2[K, V1], right iter.Seq2[K, V2], limit int) (iter.Seq2[K, Zipped[V1, V2]], *error) {
+	var err error
+
+	f := func(yield func(K, Zipped[V1, V2]) bool) {
+		var (
+			m = make(map[K][]V2)
+			n int
+		)
+		for k, v := range right {
+			if n++; limit > 0 && n > limit {
+				err = fmt.Errorf("%w: more than %d right-hand pairs", ErrHashJoinLimit, limit)
+				return
+			}
+			m[k] = append(m[k], v)
+		}
+
+		for k, v1 := range left {
+			for _, v2 := range m[k] {
+				if !yield(k, Zipped[V1, V2]{V1: v1, Ok1: true, V2: v2, Ok2: true}) {
+					return
+				}
+			}
+		}
+	}
+
+	return f, &err
+}
+
+// SemiJoin produces the pairs of left whose keys appear in right.
+// Neither input need be ordered.
+// Each pair of left appears at most once in the output,
+// no matter how many times its key appears in right.
+//
+// SemiJoin first consumes all of right,
+// building a set of its keys,
+// then consumes left.
+// If limit is positive and right contains more than limit pairs,
+// SemiJoin produces nothing
+// and the returned error pointer contains an error wrapping [ErrHashJoinLimit].
+// The caller may dereference the error pointer only after iteration is done.
+func SemiJoin[K comparable, V1, V2 any](left iter.Seq2[K, V1], right iter.Seq2[K, V2], limit int) (iter.Seq2[K, V1], *error) {
+	return hashFilterJoin(left, right, limit, true)
+}
+
+// AntiJoin produces the pairs of left whose keys do not appear in right.
+// Neither input need be ordered.
+//
+// AntiJoin first consumes all of right,
+// building a set of its keys,
+// then consumes left.
+// If limit is positive and right contains more than limit pairs,
+// AntiJoin produces nothing
+// and the returned error pointer contains an error wrapping [ErrHashJoinLimit].
+// The caller may dereference the error pointer only after iteration is done.
+func AntiJoin[K comparable, V1, V2 any](left iter.Seq2[K, V1], right iter.Seq2[K, V2], limit int) (iter.Seq2[K, V1], *error) {
+	return hashFilterJoin(left, right, limit, false)
+}
+
+// hashFilterJoin implements SemiJoin (when want is true) and AntiJoin.
+func hashFilterJoin[K comparable, V1, V2 any](left iter.Seq2[K, V1], right iter.Seq2[K, V2], limit int, want bool) (iter.Seq2[K, V1], *error) {
+	var err error
+
+	f := func(yield func(K, V1) bool) {
+		var (
+			keys = make(map[K]struct{})
+			n    int
+		)
+		for k := range right {
+			// Count pairs, not distinct keys,
+			// so that limit means the same thing as in HashJoin.
+			if n++; limit > 0 && n > limit {
+				err = fmt.Errorf("%w: more than %d right-hand pairs", ErrHashJoinLimit, limit)
+				return
+			}
+			keys[k] = struct{}{}
+		}
+
+		for k, v := range left {
+			if _, ok := keys[k]; ok == want {
+				if !yield(k, v) {
+					return
+				}
+			}
+		}
+	}
+
+	return f, &err
+}
@@ -0,0 +1,100 @@
+package seqs
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestHashJoin(t *testing.T) {
+	var (
+		left  = FromPairs(slices.Values([]Pair[int, string]{{4, "d"}, {2, "b"}, {1, "a"}, {2, "c"}, {6, "e"}}))
+		right = FromPairs(slices.Values([]Pair[int, float64]{{2, 2.5}, {5, 5.5}, {4, 4.5}, {0, 0.5}, {2, 2.75}}))
+	)
+
+	t.Run("join", func(t *testing.T) {
+		type z = Zipped[string, float64]
+
+		joined, errptr := HashJoin(left, right, 0)
+		got := slices.Collect(ToPairs(joined))
+		if *errptr != nil {
+			t.Fatal(*errptr)
+		}
+		want := []Pair[int, z]{
+			{4, z{"d", true, 4.5, true}},
+			{2, z{"b", true, 2.5, true}},
+			{2, z{"b", true, 2.75, true}},
+			{2, z{"c", true, 2.5, true}},
+			{2, z{"c", true, 2.75, true}},
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		joined, errptr := HashJoin(left, right, 4)
+		got := slices.Collect(ToPairs(joined))
+		if !errors.Is(*errptr, ErrHashJoinLimit) {
+			t.Errorf("got error %v, want %v", *errptr, ErrHashJoinLimit)
+		}
+		if len(got) != 0 {
+			t.Errorf("got %v, want nothing", got)
+		}
+	})
+
+	t.Run("semi", func(t *testing.T) {
+		joined, errptr := SemiJoin(left, right, 5)
+		got := slices.Collect(Right(joined))
+		if *errptr != nil {
+			t.Fatal(*errptr)
+		}
+		want := []string{"d", "b", "c"}
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+
+		// The limit counts pairs, as in HashJoin, not distinct keys (of which there are only 4).
+		joined, errptr = SemiJoin(left, right, 4)
+		_ = Drain2(joined)
+		if !errors.Is(*errptr, ErrHashJoinLimit) {
+			t.Errorf("got error %v, want %v", *errptr, ErrHashJoinLimit)
+		}
+	})
+
+	t.Run("anti", func(t *testing.T) {
+		joined, errptr := AntiJoin(left, right, 0)
+		got := slices.Collect(Right(joined))
+		if *errptr != nil {
+			t.Fatal(*errptr)
+		}
+		want := []string{"a", "e"}
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+
+		joined, errptr = AntiJoin(left, right, 3)
+		_ = Drain2(joined)
+		if !errors.Is(*errptr, ErrHashJoinLimit) {
+			t.Errorf("got error %v, want %v", *errptr, ErrHashJoinLimit)
+		}
+	})
+
+	t.Run("key_by", func(t *testing.T) {
+		var (
+			words   = slices.Values([]string{"Apple", "banana", "Cherry"})
+			initial = func(s string) string { return strings.ToLower(s[:1]) }
+			letters = slices.Values([]string{"a", "c"})
+		)
+		joined, errptr := SemiJoin(KeyBy(words, initial), KeyBy(letters, strings.ToLower), 0)
+		got := slices.Collect(Right(joined))
+		if *errptr != nil {
+			t.Fatal(*errptr)
+		}
+		want := []string{"Apple", "Cherry"}
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+}
@@ -0,0 +1,149 @@
+package seqs
+
+import (
+	"cmp"
+	"iter"
+)
+
+// InnerJoin joins two sequences of key-value pairs, each ordered by key.
+// For each key that appears in both left and right,
+// it produces the key together with a [Zipped] holding the left and right values,
+// with Ok1 and Ok2 both true.
+//
+// If a key appears more than once in either input,
+// the output contains every combination of its left and right values
+// (in left-major order).
+// Only the right-hand values of a single key are buffered in memory at any time.
+//
+// If the inputs are not ordered by key, the output is unspecified;
+// see [HashJoin] for unordered inputs.
+func InnerJoin[K cmp.Ordered, V1, V2 any](left iter.Seq2[K, V1], right iter.Seq2[K, V2]) iter.Seq2[K, Zipped[V1, V2]] {
+	return InnerJoinFunc(left, right, cmp.Compare[K])
+}
+
+// InnerJoinFunc is like [InnerJoin], but it uses a custom comparison function for keys.
+// The function cmp must return a negative value if its arguments are properly ordered,
+// a positive value if they are reversed,
+// and zero if they are equal.
+func InnerJoinFunc[K, V1, V2 any](left iter.Seq2[K, V1], right iter.Seq2[K, V2], cmp func(K, K) int) iter.Seq2[K, Zipped[V1, V2]] {
+	return mergeJoin(left, right, cmp, false, false)
+}
+
+// LeftJoin is like [InnerJoin],
+// but it also produces the pairs of left whose keys do not appear in right.
+// For those, the [Zipped] value has Ok2 false and a zero V2.
+func LeftJoin[K cmp.Ordered, V1, V2 any](left iter.Seq2[K, V1], right iter.Seq2[K, V2]) iter.Seq2[K, Zipped[V1, V2]] {
+	return LeftJoinFunc(left, right, cmp.Compare[K])
+}
+
+// LeftJoinFunc is like [LeftJoin], but it uses a custom comparison function for keys.
+// The function cmp must return a negative value if its arguments are properly ordered,
+// a positive value if they are reversed,
+// and zero if they are equal.
+func LeftJoinFunc[K, V1, V2 any](left iter.Seq2[K, V1], right iter.Seq2[K, V2], cmp func(K, K) int) iter.Seq2[K, Zipped[V1, V2]] {
+	return mergeJoin(left, right, cmp, true, false)
+}
+
+// RightJoin is like [InnerJoin],
+// but it also produces the pairs of right whose keys do not appear in left.
+// For those, the [Zipped] value has Ok1 false and a zero V1.
+func RightJoin[K cmp.Ordered, V1, V2 any](left iter.Seq2[K, V1], right iter.Seq2[K, V2]) iter.Seq2[K, Zipped[V1, V2]] {
+	return RightJoinFunc(left, right, cmp.Compare[K])
+}
+
+// RightJoinFunc is like [RightJoin], but it uses a custom comparison function for keys.
+// The function cmp must return a negative value if its arguments are properly ordered,
+// a positive value if they are reversed,
+// and zero if they are equal.
+func RightJoinFunc[K, V1, V2 any](left iter.Seq2[K, V1], right iter.Seq2[K, V2], cmp func(K, K) int) iter.Seq2[K, Zipped[V1, V2]] {
+	return mergeJoin(left, right, cmp, false, true)
+}
+
+// FullOuterJoin is like [InnerJoin],
+// but it also produces the pairs of either input whose keys do not appear in the other.
+// For those, the [Zipped] value has either Ok1 or Ok2 false,
+// and the corresponding value is zero.
+func FullOuterJoin[K cmp.Ordered, V1, V2 any](left iter.Seq2[K, V1], right iter.Seq2[K, V2]) iter.Seq2[K, Zipped[V1, V2]] {
+	return FullOuterJoinFunc(left, right, cmp.Compare[K])
+}
+
+// FullOuterJoinFunc is like [FullOuterJoin], but it uses a custom comparison function for keys.
+// The function cmp must return a negative value if its arguments are properly ordered,
+// a positive value if they are reversed,
+// and zero if they are equal.
+func FullOuterJoinFunc[K, V1, V2 any](left iter.Seq2[K, V1], right iter.Seq2[K, V2], cmp func(K, K) int) iter.Seq2[K, Zipped[V1, V2]] {
+	return mergeJoin(left, right, cmp, true, true)
+}
+
+// mergeJoin implements the join functions above.
+// The flags tell whether to include left-only and right-only pairs.
+func mergeJoin[K, V1, V2 any](left iter.Seq2[K, V1], right iter.Seq2[K, V2], cmp func(K, K) int, leftOnly, rightOnly bool) iter.Seq2[K, Zipped[V1, V2]] {
+	return func(yield func(K, Zipped[V1, V2]) bool) {
+		next1, stop1 := iter.Pull2(left)
+		defer stop1()
+
+		next2, stop2 := iter.Pull2(right)
+		defer stop2()
+
+		var (
+			k1, v1, ok1 = next1()
+			k2, v2, ok2 = next2()
+
+			zero1 V1
+			zero2 V2
+			run   []V2
+		)
+
+		for ok1 || ok2 {
+			if !ok1 && !rightOnly || !ok2 && !leftOnly {
+				return
+			}
+
+			var c int
+			switch {
+			case !ok2:
+				c = -1
+			case !ok1:
+				c = 1
+			default:
+				c = cmp(k1, k2)
+			}
+
+			if c < 0 {
+				if leftOnly && !yield(k1, Zipped[V1, V2]{V1: v1, Ok1: true, V2: zero2}) {
+					return
+				}
+				k1, v1, ok1 = next1()
+				continue
+			}
+
+			if c > 0 {
+				if rightOnly && !yield(k2, Zipped[V1, V2]{V1: zero1, V2: v2, Ok2: true}) {
+					return
+				}
+				k2, v2, ok2 = next2()
+				continue
+			}
+
+			// The keys are equal.
+			// Buffer the run of right values with this key,
+			// then pair each left value having this key with each of them.
+
+			key := k2
+			run = run[:0]
+			for ok2 && cmp(k2, key) == 0 {
+				run = append(run, v2)
+				k2, v2, ok2 = next2()
+			}
+
+			for ok1 && cmp(k1, key) == 0 {
+				for _, r := range run {
+					if !yield(k1, Zipped[V1, V2]{V1: v1, Ok1: true, V2: r, Ok2: true}) {
+						return
+					}
+				}
+				k1, v1, ok1 = next1()
+			}
+		}
+	}
+}
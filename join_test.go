@@ -0,0 +1,73 @@
+package seqs
+
+import (
+	"iter"
+	"slices"
+	"testing"
+)
+
+func TestJoins(t *testing.T) {
+	var (
+		left  = FromPairs(slices.Values([]Pair[int, string]{{1, "a"}, {2, "b"}, {2, "c"}, {4, "d"}, {6, "e"}}))
+		right = FromPairs(slices.Values([]Pair[int, float64]{{0, 0.5}, {2, 2.5}, {2, 2.75}, {4, 4.5}, {5, 5.5}}))
+	)
+
+	type z = Zipped[string, float64]
+
+	var (
+		only1 = func(s string) z { return z{V1: s, Ok1: true} }
+		only2 = func(f float64) z { return z{V2: f, Ok2: true} }
+		both  = func(s string, f float64) z { return z{V1: s, Ok1: true, V2: f, Ok2: true} }
+	)
+
+	cases := []struct {
+		name string
+		join func(iter.Seq2[int, string], iter.Seq2[int, float64]) iter.Seq2[int, z]
+		want []Pair[int, z]
+	}{{
+		name: "inner",
+		join: InnerJoin[int, string, float64],
+		want: []Pair[int, z]{
+			{2, both("b", 2.5)}, {2, both("b", 2.75)}, {2, both("c", 2.5)}, {2, both("c", 2.75)},
+			{4, both("d", 4.5)},
+		},
+	}, {
+		name: "left",
+		join: LeftJoin[int, string, float64],
+		want: []Pair[int, z]{
+			{1, only1("a")},
+			{2, both("b", 2.5)}, {2, both("b", 2.75)}, {2, both("c", 2.5)}, {2, both("c", 2.75)},
+			{4, both("d", 4.5)},
+			{6, only1("e")},
+		},
+	}, {
+		name: "right",
+		join: RightJoin[int, string, float64],
+		want: []Pair[int, z]{
+			{0, only2(0.5)},
+			{2, both("b", 2.5)}, {2, both("b", 2.75)}, {2, both("c", 2.5)}, {2, both("c", 2.75)},
+			{4, both("d", 4.5)},
+			{5, only2(5.5)},
+		},
+	}, {
+		name: "full",
+		join: FullOuterJoin[int, string, float64],
+		want: []Pair[int, z]{
+			{0, only2(0.5)},
+			{1, only1("a")},
+			{2, both("b", 2.5)}, {2, both("b", 2.75)}, {2, both("c", 2.5)}, {2, both("c", 2.75)},
+			{4, both("d", 4.5)},
+			{5, only2(5.5)},
+			{6, only1("e")},
+		},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := slices.Collect(ToPairs(tc.join(left, right)))
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package seqs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+)
+
+// JSONArray produces an iterator over the elements of a JSON array in r,
+// each decoded into a value of type T.
+// The input is read incrementally with a [json.Decoder],
+// so only one element at a time is held in memory,
+// even when the array is very large.
+//
+// The path locates the array within the JSON value in r.
+// It is a dot-separated sequence of object keys,
+// so that given the input
+//
+//	{"data": {"count": 2, "results": [1, 2]}}
+//
+// the path "data.results" locates the array [1, 2].
+// An empty path means the value in r is itself the array.
+// Other parts of the input are skipped over without being decoded.
+// Reading stops at the end of the array.
+//
+// The caller can dereference the returned error pointer to check for errors
+// but only after iteration is done.
+func JSONArray[T any](r io.Reader, path string) (iter.Seq[T], *error) {
+	var err error
+
+	f := func(yield func(T) bool) {
+		dec := json.NewDecoder(r)
+		if err = jsonSeek(dec, path, '['); err != nil {
+			return
+		}
+
+		for i := 0; dec.More(); i++ {
+			var val T
+			if err = dec.Decode(&val); err != nil {
+				err = fmt.Errorf("decoding element %d of %s: %w", i, jsonPathName(path), err)
+				return
+			}
+			if !yield(val) {
+				return
+			}
+		}
+
+		err = jsonExpectDelim(dec, ']')
+	}
+
+	return f, &err
+}
+
+// JSONObject produces an iterator over the members of a JSON object in r,
+// as pairs of keys and values,
+// with the values decoded into type T.
+// The path locates the object within the JSON value in r,
+// as described for [JSONArray].
+// As with JSONArray,
+// the input is read incrementally,
+// so only one member at a time is held in memory.
+//
+// The caller can dereference the returned error pointer to check for errors
+// but only after iteration is done.
+func JSONObject[T any](r io.Reader, path string) (iter.Seq2[string, T], *error) {
+	var err error
+
+	f := func(yield func(string, T) bool) {
+		dec := json.NewDecoder(r)
+		if err = jsonSeek(dec, path, '{'); err != nil {
+			return
+		}
+
+		for dec.More() {
+			var key string
+			if key, err = jsonKey(dec); err != nil {
+				return
+			}
+
+			var val T
+			if err = dec.Decode(&val); err != nil {
+				err = fmt.Errorf("decoding member %q of %s: %w", key, jsonPathName(path), err)
+				return
+			}
+			if !yield(key, val) {
+				return
+			}
+		}
+
+		err = jsonExpectDelim(dec, '}')
+	}
+
+	return f, &err
+}
+
+// jsonSeek advances dec past the opening delimiter of the array or object
+// (according to delim)
+// at the given path.
+func jsonSeek(dec *json.Decoder, path string, delim json.Delim) error {
+	var keys []string
+	if path != "" {
+		keys = strings.Split(path, ".")
+	}
+
+	for i, key := range keys {
+		if err := jsonExpectDelim(dec, '{'); err != nil {
+			return fmt.Errorf("at %s: %w", jsonPathName(strings.Join(keys[:i], ".")), err)
+		}
+
+		for {
+			if !dec.More() {
+				return fmt.Errorf("key %s not found", jsonPathName(strings.Join(keys[:i+1], ".")))
+			}
+
+			k, err := jsonKey(dec)
+			if err != nil {
+				return err
+			}
+			if k == key {
+				break
+			}
+			if err := jsonSkip(dec); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := jsonExpectDelim(dec, delim); err != nil {
+		return fmt.Errorf("at %s: %w", jsonPathName(path), err)
+	}
+	return nil
+}
+
+// jsonSkip reads and discards the next value from dec,
+// without decoding it.
+func jsonSkip(dec *json.Decoder) error {
+	var depth int
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if d, ok := tok.(json.Delim); ok {
+			switch d {
+			case '[', '{':
+				depth++
+			case ']', '}':
+				depth--
+			}
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// jsonKey reads an object key from dec.
+func jsonKey(dec *json.Decoder) (string, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return "", err
+	}
+	key, ok := tok.(string)
+	if !ok {
+		return "", fmt.Errorf("got %v, want object key", tok)
+	}
+	return key, nil
+}
+
+func jsonExpectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return err
+	}
+	if tok != delim {
+		return fmt.Errorf("got %v, want %v", tok, delim)
+	}
+	return nil
+}
+
+func jsonPathName(path string) string {
+	if path == "" {
+		return "top level"
+	}
+	return fmt.Sprintf("%q", path)
+}
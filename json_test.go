@@ -0,0 +1,145 @@
+package seqs
+
+import (
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const jsonTestInput = `{
+  "meta": {"skip": [1, {"a": [2, 3]}], "n": null},
+  "data": {
+    "count": 3,
+    "results": [
+      {"name": "alice", "age": 30},
+      {"name": "bob", "age": 40},
+      {"name": "carol", "age": 50}
+    ],
+    "totals": {"x": 1, "y": 2}
+  }
+}`
+
+type jsonTestPerson struct {
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+func TestJSONArray(t *testing.T) {
+	people, errptr := JSONArray[jsonTestPerson](strings.NewReader(jsonTestInput), "data.results")
+	got := slices.Collect(people)
+	if err := *errptr; err != nil {
+		t.Fatal(err)
+	}
+
+	want := []jsonTestPerson{{"alice", 30}, {"bob", 40}, {"carol", 50}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestJSONArrayTopLevel(t *testing.T) {
+	ints, errptr := JSONArray[int](strings.NewReader(" [1, 2, 3] "), "")
+	got := slices.Collect(ints)
+	if err := *errptr; err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{1, 2, 3}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestJSONArrayStreaming(t *testing.T) {
+	// The input after the array is never read,
+	// and the input after the first element is not read if iteration stops there.
+	const input = `{"items": [1, 2, 3], "rest": [`
+
+	ints, errptr := JSONArray[int](strings.NewReader(input), "items")
+	if got, want := slices.Collect(ints), []int{1, 2, 3}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if err := *errptr; err != nil {
+		t.Fatal(err)
+	}
+
+	ints, errptr = JSONArray[int](strings.NewReader(`{"items": [1, 2, `), "items")
+	for val := range ints {
+		if val != 1 {
+			t.Errorf("got %d, want 1", val)
+		}
+		break
+	}
+	if err := *errptr; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestJSONArrayErrors(t *testing.T) {
+	cases := []struct {
+		name, input, path, wantErr string
+	}{{
+		name:    "not found",
+		input:   jsonTestInput,
+		path:    "data.bogus",
+		wantErr: `key "data.bogus" not found`,
+	}, {
+		name:    "not an array",
+		input:   jsonTestInput,
+		path:    "data.count",
+		wantErr: `at "data.count": got 3, want [`,
+	}, {
+		name:    "not an object",
+		input:   jsonTestInput,
+		path:    "data.count.x",
+		wantErr: `at "data.count": got 3, want {`,
+	}, {
+		name:    "bad element",
+		input:   `{"items": [1, "two"]}`,
+		path:    "items",
+		wantErr: `decoding element 1 of "items"`,
+	}, {
+		name:    "truncated",
+		input:   `{"items": [1, 2`,
+		path:    "items",
+		wantErr: `decoding element 2 of "items"`,
+	}, {
+		name:    "truncated before array",
+		input:   `{"items": `,
+		path:    "items",
+		wantErr: io.ErrUnexpectedEOF.Error(),
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ints, errptr := JSONArray[int](strings.NewReader(tc.input), tc.path)
+			for range ints {
+			}
+			err := *errptr
+			if err == nil {
+				t.Fatal("got no error")
+			}
+			if !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("got error %q, want one containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestJSONObject(t *testing.T) {
+	members, errptr := JSONObject[int](strings.NewReader(jsonTestInput), "data.totals")
+
+	var got []Pair[string, int]
+	for k, v := range members {
+		got = append(got, Pair[string, int]{X: k, Y: v})
+	}
+	if err := *errptr; err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Pair[string, int]{{X: "x", Y: 1}, {X: "y", Y: 2}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -2,7 +2,10 @@ package seqs
 
 import (
 	"bufio"
+	"bytes"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"iter"
 )
@@ -150,3 +153,82 @@ func LongLines(r io.Reader) (iter.Seq[io.Reader], *error) {
 		}
 	})
 }
+
+// JSONLines produces an iterator over the values in r,
+// which is in JSON Lines format:
+// one JSON value per line.
+// Each line is decoded into a value of type T with [json.Unmarshal].
+// Blank lines are skipped.
+// Unlike [Lines],
+// this is not subject to a line-length limit.
+//
+// A decoding error stops the iteration
+// and is reported with the number of the line where it occurred
+// (counting from 1).
+//
+// The caller can dereference the returned error pointer to check for errors
+// but only after iteration is done.
+func JSONLines[T any](r io.Reader) (iter.Seq[T], *error) {
+	var err error
+
+	f := func(yield func(T) bool) {
+		br := bufio.NewReader(r)
+
+		for lineNum := 1; ; lineNum++ {
+			line, err2 := br.ReadBytes('\n')
+			if err2 != nil && !errors.Is(err2, io.EOF) {
+				err = err2
+				return
+			}
+
+			if trimmed := bytes.TrimSpace(line); len(trimmed) > 0 {
+				var val T
+				if err = json.Unmarshal(trimmed, &val); err != nil {
+					err = fmt.Errorf("line %d: %w", lineNum, err)
+					return
+				}
+				if !yield(val) {
+					return
+				}
+			}
+
+			if err2 != nil { // io.EOF
+				return
+			}
+		}
+	}
+
+	return f, &err
+}
+
+// WriteJSONLines writes the values in inp to w in JSON Lines format:
+// each value is encoded with [json.Marshal] and written on a line by itself.
+//
+// Output is buffered.
+// If flush is true,
+// the buffer is flushed after each value,
+// so that a reader at the other end of a pipe or network connection
+// sees each value as soon as it is produced.
+// Otherwise the buffer is flushed only when it is full
+// and at the end.
+func WriteJSONLines[T any](w io.Writer, inp iter.Seq[T], flush bool) error {
+	var (
+		bw  = bufio.NewWriter(w)
+		enc = json.NewEncoder(bw) // Encode appends a newline to each value.
+		n   int
+	)
+
+	for val := range inp {
+		if err := enc.Encode(val); err != nil {
+			return fmt.Errorf("encoding value %d: %w", n, err)
+		}
+		if flush {
+			if err := bw.Flush(); err != nil {
+				return fmt.Errorf("writing value %d: %w", n, err)
+			}
+		}
+		n++
+	}
+
+	return bw.Flush()
+}
@@ -2,10 +2,13 @@ package seqs
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"slices"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -76,3 +79,91 @@ func TestLongLines(t *testing.T) {
 		t.Errorf("mismatch (-want +got):\n%s", diff)
 	}
 }
+
+func TestJSONLines(t *testing.T) {
+	type rec struct {
+		A int    `json:"a"`
+		B string `json:"b"`
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		const input = "{\"a\": 1, \"b\": \"x\"}\n\n  \r\n{\"a\": 2, \"b\": \"y\"}\r\n{\"a\": 3}"
+
+		recs, errptr := JSONLines[rec](strings.NewReader(input))
+		got := slices.Collect(recs)
+		if err := *errptr; err != nil {
+			t.Fatal(err)
+		}
+
+		want := []rec{{A: 1, B: "x"}, {A: 2, B: "y"}, {A: 3}}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		const input = "{\"a\": 1}\n\n{\"a\": \"two\"}\n{\"a\": 3}\n"
+
+		recs, errptr := JSONLines[rec](strings.NewReader(input))
+		got := slices.Collect(recs)
+		if len(got) != 1 {
+			t.Errorf("got %d values, want 1", len(got))
+		}
+
+		err := *errptr
+		if err == nil {
+			t.Fatal("got no error")
+		}
+		if !strings.HasPrefix(err.Error(), "line 3: ") {
+			t.Errorf("error %q does not report line 3", err)
+		}
+		var typeErr *json.UnmarshalTypeError
+		if !errors.As(err, &typeErr) {
+			t.Errorf("got error %v, want a *json.UnmarshalTypeError", err)
+		}
+	})
+}
+
+type flushCounter struct {
+	bytes.Buffer
+	writes int
+}
+
+func (f *flushCounter) Write(p []byte) (int, error) {
+	f.writes++
+	return f.Buffer.Write(p)
+}
+
+func TestWriteJSONLines(t *testing.T) {
+	vals := []map[string]int{{"a": 1}, {"b": 2}, {"c": 3}}
+
+	for _, flush := range []bool{false, true} {
+		t.Run(fmt.Sprintf("flush=%t", flush), func(t *testing.T) {
+			w := new(flushCounter)
+			if err := WriteJSONLines(w, slices.Values(vals), flush); err != nil {
+				t.Fatal(err)
+			}
+
+			const want = "{\"a\":1}\n{\"b\":2}\n{\"c\":3}\n"
+			if got := w.String(); got != want {
+				t.Errorf("got %q, want %q", got, want)
+			}
+
+			wantWrites := 1
+			if flush {
+				wantWrites = len(vals)
+			}
+			if w.writes != wantWrites {
+				t.Errorf("got %d writes, want %d", w.writes, wantWrites)
+			}
+
+			decoded, errptr := JSONLines[map[string]int](&w.Buffer)
+			if diff := cmp.Diff(vals, slices.Collect(decoded)); diff != "" {
+				t.Errorf("round trip mismatch (-want +got):\n%s", diff)
+			}
+			if err := *errptr; err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package seqs
 
-import "iter"
+import (
+	"context"
+	"iter"
+	"runtime"
+	"sync"
+)
 
 // Map returns an iterator over the values of inp transformed by the function f.
 func Map[T, U any](inp iter.Seq[T], f func(T) U) iter.Seq[U] {
@@ -34,6 +39,178 @@ func Mapx[T, U any](inp iter.Seq[T], f func(T) (U, error)) (iter.Seq[U], *error)
 	return g, &err
 }
 
+// ParMap is like [Map] but calls f concurrently on up to n goroutines.
+// Results are still produced in the order of the input sequence.
+// If n is less than 1, the value of [runtime.GOMAXPROCS] is used.
+//
+// See [ParMapx] for details.
+func ParMap[T, U any](inp iter.Seq[T], n int, f func(T) U) iter.Seq[U] {
+	seq, _ := ParMapx(inp, n, func(val T) (U, error) {
+		return f(val), nil
+	})
+	return seq
+}
+
+// ParMapx is the extended form of [ParMap].
+// It produces an iterator of values transformed from an input iterator by a mapping function,
+// which is called concurrently on up to n goroutines.
+// If n is less than 1, the value of [runtime.GOMAXPROCS] is used.
+//
+// Results are produced in the order of the input sequence.
+// At most n results may be buffered awaiting their turn,
+// so a slow call to f holds up the remaining workers rather than causing memory to grow without bound.
+//
+// The input sequence is consumed in a separate goroutine.
+// If the mapping function returns an error,
+// iteration stops and the error is available by dereferencing the returned pointer,
+// but only after iteration is done.
+// When iteration stops early, for that reason or because the caller stops ranging,
+// no new calls to f are started,
+// though calls already in progress run to completion in the background.
+func ParMapx[T, U any](inp iter.Seq[T], n int, f func(T) (U, error)) (iter.Seq[U], *error) {
+	if n < 1 {
+		n = runtime.GOMAXPROCS(0)
+	}
+
+	var err error
+
+	g := func(yield func(U) bool) {
+		type result struct {
+			val U
+			err error
+		}
+
+		var (
+			done    = make(chan struct{})
+			sem     = make(chan struct{}, n)
+			pending = make(chan chan result, n)
+		)
+
+		defer close(done)
+
+		go func() {
+			defer close(pending)
+
+			for val := range inp {
+				select {
+				case sem <- struct{}{}:
+				case <-done:
+					return
+				}
+
+				ch := make(chan result, 1)
+
+				select {
+				case pending <- ch:
+				case <-done:
+					<-sem
+					return
+				}
+
+				// This extra check ensures that an early exit "wins"
+				// when it happens while one of the selects above could also proceed,
+				// since select chooses randomly among ready cases.
+				select {
+				case <-done:
+					<-sem
+					return
+				default:
+				}
+
+				go func() {
+					defer func() { <-sem }()
+
+					out, ferr := f(val)
+					ch <- result{val: out, err: ferr}
+				}()
+			}
+		}()
+
+		for ch := range pending {
+			res := <-ch
+			if res.err != nil {
+				err = res.err
+				return
+			}
+			if !yield(res.val) {
+				return
+			}
+		}
+	}
+
+	return g, &err
+}
+
+// ParMapUnordered produces an iterator of values transformed from an input iterator by a mapping function,
+// which is called concurrently on up to n goroutines.
+// If n is less than 1, the value of [runtime.GOMAXPROCS] is used.
+//
+// Unlike [ParMapx], results are produced in the order in which the calls to f finish,
+// not in the order of the input sequence.
+//
+// The function f receives a context that is canceled when iteration stops:
+// when the caller stops ranging over the output,
+// when the given context is canceled,
+// or when any call to f returns an error.
+// In that last case the error is available by dereferencing the returned pointer.
+// The pointer may also contain the error from ctx
+// (such as [context.Canceled] or [context.DeadlineExceeded]).
+// If more than one call to f fails,
+// only the first error is reported.
+// The caller may check the error only after iteration is done.
+func ParMapUnordered[T, U any](ctx context.Context, inp iter.Seq[T], n int, f func(context.Context, T) (U, error)) (iter.Seq[U], *error) {
+	if n < 1 {
+		n = runtime.GOMAXPROCS(0)
+	}
+
+	var err error
+
+	g := func(yield func(U) bool) {
+		ctx, cancel := context.WithCancelCause(ctx)
+		defer cancel(nil)
+
+		inch, _ := ToChanContext(ctx, inp)
+
+		outs, _ := Go(func(ch chan<- U) error {
+			var wg sync.WaitGroup
+
+			for i := 0; i < n; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+
+					for val := range inch {
+						out, ferr := f(ctx, val)
+						if ferr != nil {
+							cancel(ferr)
+							return
+						}
+
+						select {
+						case ch <- out:
+						case <-ctx.Done():
+							return
+						}
+					}
+				}()
+			}
+
+			wg.Wait()
+			return nil
+		})
+
+		for out := range outs {
+			if !yield(out) {
+				return
+			}
+		}
+
+		err = context.Cause(ctx)
+	}
+
+	return g, &err
+}
+
 // Map2 returns an iterator over the pairs of values of inp transformed by the function f.
 func Map2[T1, U1, T2, U2 any](inp iter.Seq2[T1, U1], f func(T1, U1) (T2, U2)) iter.Seq2[T2, U2] {
 	seq, _ := Map2x(inp, func(t1 T1, u1 U1) (T2, U2, error) {
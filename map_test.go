@@ -1,8 +1,12 @@
 package seqs
 
 import (
+	"context"
+	"errors"
 	"slices"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestMap(t *testing.T) {
@@ -29,3 +33,148 @@ func TestMap2(t *testing.T) {
 		t.Errorf("got %v, want %v", got, want)
 	}
 }
+
+func TestParMap(t *testing.T) {
+	var (
+		inp  = slices.Values(slices.Collect(Limit(Ints(0, 1), 100)))
+		want = slices.Collect(Map(inp, func(x int) int { return x * x }))
+	)
+
+	var running, maxRunning int32
+
+	m := ParMap(inp, 4, func(x int) int {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			old := atomic.LoadInt32(&maxRunning)
+			if n <= old || atomic.CompareAndSwapInt32(&maxRunning, old, n) {
+				break
+			}
+		}
+
+		// Make earlier elements slower than later ones.
+		time.Sleep(time.Duration(100-x) * 10 * time.Microsecond)
+		return x * x
+	})
+
+	got := slices.Collect(m)
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if maxRunning > 4 {
+		t.Errorf("got %d concurrent calls, want at most 4", maxRunning)
+	}
+}
+
+func TestParMapx(t *testing.T) {
+	t.Run("error", func(t *testing.T) {
+		errBad := errors.New("bad")
+
+		m, errptr := ParMapx(Limit(Ints(0, 1), 100), 3, func(x int) (int, error) {
+			if x == 10 {
+				return 0, errBad
+			}
+			return x, nil
+		})
+		got := slices.Collect(m)
+		if !errors.Is(*errptr, errBad) {
+			t.Errorf("got error %v, want %v", *errptr, errBad)
+		}
+		want := slices.Collect(Limit(Ints(0, 1), 10))
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("early_exit", func(t *testing.T) {
+		var calls int32
+
+		m, errptr := ParMapx(Ints(0, 1), 2, func(x int) (int, error) {
+			atomic.AddInt32(&calls, 1)
+			return x, nil
+		})
+		got := slices.Collect(Limit(m, 5))
+		if *errptr != nil {
+			t.Fatal(*errptr)
+		}
+		want := []int{0, 1, 2, 3, 4}
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		n1 := atomic.LoadInt32(&calls)
+		time.Sleep(10 * time.Millisecond)
+		if n2 := atomic.LoadInt32(&calls); n2 != n1 {
+			t.Errorf("calls continued after early exit (%d, then %d)", n1, n2)
+		}
+	})
+}
+
+func TestParMapUnordered(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("all", func(t *testing.T) {
+		m, errptr := ParMapUnordered(ctx, Limit(Ints(0, 1), 100), 4, func(_ context.Context, x int) (int, error) {
+			time.Sleep(time.Duration(x%7) * 100 * time.Microsecond)
+			return x * x, nil
+		})
+		got := slices.Sorted(m)
+		if *errptr != nil {
+			t.Fatal(*errptr)
+		}
+		want := slices.Collect(Map(Limit(Ints(0, 1), 100), func(x int) int { return x * x }))
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		errBad := errors.New("bad")
+
+		m, errptr := ParMapUnordered(ctx, Ints(0, 1), 4, func(ctx context.Context, x int) (int, error) {
+			if x == 10 {
+				return 0, errBad
+			}
+			return x, nil
+		})
+		_ = Drain(m)
+		if !errors.Is(*errptr, errBad) {
+			t.Errorf("got error %v, want %v", *errptr, errBad)
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		m, errptr := ParMapUnordered(ctx, Ints(0, 1), 2, func(ctx context.Context, x int) (int, error) {
+			if x == 5 {
+				cancel()
+			}
+			return x, nil
+		})
+		_ = Drain(m)
+		if !errors.Is(*errptr, context.Canceled) {
+			t.Errorf("got error %v, want %v", *errptr, context.Canceled)
+		}
+	})
+
+	t.Run("early_exit", func(t *testing.T) {
+		m, errptr := ParMapUnordered(ctx, Ints(0, 1), 2, func(ctx context.Context, x int) (int, error) {
+			if x >= 10 {
+				// Block until the caller's early exit cancels the context.
+				<-ctx.Done()
+				return 0, ctx.Err()
+			}
+			return x, nil
+		})
+		got := slices.Collect(Limit(m, 3))
+		if *errptr != nil {
+			t.Fatal(*errptr)
+		}
+		if len(got) != 3 {
+			t.Errorf("got %v, want 3 values", got)
+		}
+	})
+}
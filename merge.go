@@ -2,8 +2,8 @@ package seqs
 
 import (
 	"cmp"
+	"container/heap"
 	"iter"
-	"slices"
 )
 
 // Merge merges two sequences of ordered values.
@@ -107,16 +107,27 @@ func MergeFunc2[K, V any](x, y iter.Seq2[K, V], f func(K, K) int) iter.Seq2[K, V
 }
 
 // MergeAll merges zero or more ordered sequences into a single one.
+// When equal values appear in more than one input,
+// the output contains them in the order of the inputs.
+//
+// MergeAll is equivalent to calling MergeAllFunc with cmp.Compare[T]
+// as the ordering function.
 func MergeAll[T cmp.Ordered](seqs ...iter.Seq[T]) iter.Seq[T] {
 	return MergeAllFunc(seqs, cmp.Compare[T])
 }
 
-// MergeAllFunc merges zero or more ordered sequences into a single one,
-// using the ordering function f,
-// which should return zero if its arguments are equal,
+// MergeAllFunc merges zero or more sequences of values ordered by the function f into a single one.
+// When equal values appear in more than one input,
+// the output contains them in the order of the inputs,
+// so that values from inps[0] come before values from inps[1], and so on.
+//
+// The pending value from each input is kept in a heap,
+// so each output value costs O(log k) comparisons for k inputs.
+//
+// The function f should return zero if its arguments are equal,
 // a negative value if the arguments are in the proper order,
 // and a positive value if they are in the reverse order.
-func MergeAllFunc[T cmp.Ordered](inps []iter.Seq[T], f func(T, T) int) iter.Seq[T] {
+func MergeAllFunc[T any](inps []iter.Seq[T], f func(T, T) int) iter.Seq[T] {
 	switch len(inps) {
 	case 0:
 		return Empty[T]
@@ -126,49 +137,97 @@ func MergeAllFunc[T cmp.Ordered](inps []iter.Seq[T], f func(T, T) int) iter.Seq[
 		return MergeFunc(inps[0], inps[1], f)
 	default:
 		return func(yield func(T) bool) {
-			var (
-				nexts = make([]func() (T, bool), 0, len(inps))
-				vals  = make([]T, 0, len(inps))
-			)
+			h := &mergeHeap[T]{
+				items: make([]mergeItem[T], 0, len(inps)),
+				f:     f,
+			}
 
-			for _, inp := range inps {
+			for i, inp := range inps {
 				next, stop := iter.Pull(inp)
 				defer stop()
 
-				val, ok := next()
-				if ok {
-					vals = append(vals, val)
-					nexts = append(nexts, next)
+				if val, ok := next(); ok {
+					h.items = append(h.items, mergeItem[T]{val: val, index: i, next: next})
 				}
 			}
 
-			for len(vals) > 0 {
-				var (
-					best      = vals[0]
-					bestIndex = 0
-				)
-				for i := 1; i < len(vals); i++ {
-					val := vals[i]
-					if f(val, best) < 0 {
-						best = val
-						bestIndex = i
-					}
-				}
+			heap.Init(h)
 
-				if !yield(best) {
+			for len(h.items) > 0 {
+				top := &h.items[0]
+				if !yield(top.val) {
 					return
 				}
 
-				// Replace the best value with the next value from its input.
-				nextval, ok := nexts[bestIndex]()
-				if ok {
-					vals[bestIndex] = nextval
+				// Replace the yielded value with the next value from its input.
+				if val, ok := top.next(); ok {
+					top.val = val
+					heap.Fix(h, 0)
 				} else {
 					// That input is exhausted.
-					nexts = slices.Delete(nexts, bestIndex, bestIndex+1)
-					vals = slices.Delete(vals, bestIndex, bestIndex+1)
+					heap.Pop(h)
 				}
 			}
 		}
 	}
 }
+
+// MergeAll2 merges zero or more sequences of key-value pairs ordered by their keys into a single one.
+// When equal keys appear in more than one input,
+// the output contains their pairs in the order of the inputs.
+//
+// MergeAll2 is equivalent to calling MergeAllFunc2 with cmp.Compare[K]
+// as the ordering function.
+func MergeAll2[K cmp.Ordered, V any](seqs ...iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return MergeAllFunc2(seqs, cmp.Compare[K])
+}
+
+// MergeAllFunc2 merges zero or more sequences of key-value pairs ordered by the function f into a single one.
+// When equal keys appear in more than one input,
+// the output contains their pairs in the order of the inputs.
+//
+// The function f should return zero if its arguments are equal,
+// a negative value if the arguments are in the proper order,
+// and a positive value if they are in the reverse order.
+func MergeAllFunc2[K, V any](inps []iter.Seq2[K, V], f func(K, K) int) iter.Seq2[K, V] {
+	pairs := make([]iter.Seq[Pair[K, V]], 0, len(inps))
+	for _, inp := range inps {
+		pairs = append(pairs, ToPairs(inp))
+	}
+	merged := MergeAllFunc(pairs, func(a, b Pair[K, V]) int {
+		return f(a.X, b.X)
+	})
+	return FromPairs(merged)
+}
+
+type mergeItem[T any] struct {
+	val   T
+	index int // position of the input in the list of inputs, for breaking ties
+	next  func() (T, bool)
+}
+
+// mergeHeap implements [heap.Interface].
+type mergeHeap[T any] struct {
+	items []mergeItem[T]
+	f     func(T, T) int
+}
+
+func (h *mergeHeap[T]) Len() int { return len(h.items) }
+
+func (h *mergeHeap[T]) Less(i, j int) bool {
+	if c := h.f(h.items[i].val, h.items[j].val); c != 0 {
+		return c < 0
+	}
+	return h.items[i].index < h.items[j].index
+}
+
+func (h *mergeHeap[T]) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+
+func (h *mergeHeap[T]) Push(x any) { h.items = append(h.items, x.(mergeItem[T])) }
+
+func (h *mergeHeap[T]) Pop() any {
+	n := len(h.items)
+	item := h.items[n-1]
+	h.items = h.items[:n-1]
+	return item
+}
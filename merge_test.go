@@ -1,6 +1,8 @@
 package seqs
 
 import (
+	"cmp"
+	"iter"
 	"slices"
 	"testing"
 )
@@ -74,3 +76,46 @@ func TestMerge2(t *testing.T) {
 		t.Errorf("got %v, want %v", got, want)
 	}
 }
+
+func TestMergeAllFunc(t *testing.T) {
+	type rec struct {
+		key int
+		src string
+	}
+
+	var (
+		a = slices.Values([]rec{{1, "a"}, {3, "a"}, {3, "a"}, {5, "a"}})
+		b = slices.Values([]rec{{2, "b"}, {3, "b"}, {6, "b"}})
+		c = slices.Values([]rec{{1, "c"}, {3, "c"}})
+		d = slices.Values([]rec{{0, "d"}, {7, "d"}})
+		m = MergeAllFunc([]iter.Seq[rec]{a, b, c, d}, func(x, y rec) int {
+			return cmp.Compare(x.key, y.key)
+		})
+		got  = slices.Collect(m)
+		want = []rec{{0, "d"}, {1, "a"}, {1, "c"}, {2, "b"}, {3, "a"}, {3, "a"}, {3, "b"}, {3, "c"}, {5, "a"}, {6, "b"}, {7, "d"}}
+	)
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// Early exit.
+	got = slices.Collect(Limit(m, 3))
+	if !slices.Equal(got, want[:3]) {
+		t.Errorf("got %v, want %v", got, want[:3])
+	}
+}
+
+func TestMergeAll2(t *testing.T) {
+	var (
+		x     = slices.Values([]Pair[int, string]{{1, "one"}, {4, "four"}})
+		y     = slices.Values([]Pair[int, string]{{2, "two"}, {4, "FOUR"}, {5, "five"}})
+		z     = slices.Values([]Pair[int, string]{{3, "three"}, {6, "six"}})
+		m     = MergeAll2(FromPairs(x), FromPairs(y), FromPairs(z))
+		names = Right(m)
+		got   = slices.Collect(names)
+		want  = []string{"one", "two", "three", "four", "FOUR", "five", "six"}
+	)
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,198 @@
+package seqs
+
+import (
+	"cmp"
+	"iter"
+	"math"
+	"slices"
+)
+
+// QuantileSketch is a compact, approximate summary of a sequence of numbers,
+// from which quantiles (such as the median or the 99th percentile) can be estimated.
+// Sketches of separate parts of a sequence may be combined with [QuantileSketch.Merge].
+//
+// This is a KLL sketch,
+// as described in "Optimal Quantile Approximation in Streams"
+// by Karnin, Lang, and Liberty.
+// Its size grows only logarithmically with the number of values added,
+// and its rank error is roughly proportional to 1/k
+// for the accuracy parameter k given to [NewQuantileSketch].
+//
+// The zero QuantileSketch is empty and ready to use,
+// with the default accuracy parameter
+// (but see [QuantileSketch.Merge]).
+type QuantileSketch struct {
+	k      int
+	levels [][]float64 // values at level h each stand for 2^h values of the input
+	count  int         // the number of values added
+	size   int         // the number of values held in levels
+	coin   bool        // alternates to choose which half of a level to keep when compacting
+}
+
+// NewQuantileSketch creates a new, empty [QuantileSketch] with accuracy parameter k.
+// If k is less than 8, a default of 200 is used,
+// which gives a rank error of around 1.5%.
+func NewQuantileSketch(k int) *QuantileSketch {
+	s := new(QuantileSketch)
+	s.init(k)
+	return s
+}
+
+// init prepares s for use, if it has not been already,
+// with accuracy parameter k.
+func (s *QuantileSketch) init(k int) {
+	if s.k > 0 {
+		return
+	}
+	if k < 8 {
+		k = 200
+	}
+	s.k = k
+	s.levels = [][]float64{nil}
+}
+
+// SketchQuantiles produces a [QuantileSketch] with accuracy parameter k
+// (see [NewQuantileSketch])
+// from the numbers in inp.
+// It consumes the entire input sequence.
+// Beware of infinite input!
+func SketchQuantiles[T Number](inp iter.Seq[T], k int) *QuantileSketch {
+	s := NewQuantileSketch(k)
+	for x := range inp {
+		s.Add(float64(x))
+	}
+	return s
+}
+
+// Add adds a value to the sketch.
+func (s *QuantileSketch) Add(x float64) {
+	s.init(0)
+	s.levels[0] = append(s.levels[0], x)
+	s.count++
+	s.size++
+	s.compress()
+}
+
+// Merge adds the contents of other to s.
+// The other sketch is unchanged.
+//
+// The merged sketch keeps the accuracy parameter of s,
+// unless s is a zero QuantileSketch,
+// in which case it adopts that of other.
+// If other has a smaller accuracy parameter than s,
+// the merged sketch may be less accurate than its parameter suggests,
+// since the values from other were already compacted more aggressively.
+func (s *QuantileSketch) Merge(other *QuantileSketch) {
+	s.init(other.k)
+	for len(s.levels) < len(other.levels) {
+		s.levels = append(s.levels, nil)
+	}
+	for h, level := range other.levels {
+		s.levels[h] = append(s.levels[h], level...)
+	}
+	s.count += other.count
+	s.size += other.size
+	s.compress()
+}
+
+// Count returns the number of values that have been added to the sketch.
+func (s *QuantileSketch) Count() int {
+	return s.count
+}
+
+// Quantile returns an estimate of the q-quantile of the values in the sketch,
+// for q between 0 and 1.
+// For example, Quantile(0.5) estimates the median.
+// If the sketch is empty, Quantile returns NaN.
+func (s *QuantileSketch) Quantile(q float64) float64 {
+	if s.count == 0 {
+		return math.NaN()
+	}
+
+	type weighted struct {
+		val    float64
+		weight int
+	}
+
+	var (
+		items = make([]weighted, 0, s.size)
+		total int
+	)
+	for h, level := range s.levels {
+		for _, val := range level {
+			items = append(items, weighted{val: val, weight: 1 << h})
+			total += 1 << h
+		}
+	}
+	slices.SortFunc(items, func(a, b weighted) int {
+		return cmp.Compare(a.val, b.val)
+	})
+
+	var (
+		target = q * float64(total)
+		cum    int
+	)
+	for _, item := range items {
+		cum += item.weight
+		if float64(cum) >= target {
+			return item.val
+		}
+	}
+	return items[len(items)-1].val
+}
+
+// capacity returns the number of values that level h may hold before it must be compacted.
+// Lower levels have exponentially smaller capacities than the top level, which has capacity k.
+func (s *QuantileSketch) capacity(h int) int {
+	depth := len(s.levels) - 1 - h
+	return max(2, int(math.Ceil(float64(s.k)*math.Pow(2.0/3.0, float64(depth)))))
+}
+
+func (s *QuantileSketch) totalCapacity() int {
+	var total int
+	for h := range s.levels {
+		total += s.capacity(h)
+	}
+	return total
+}
+
+// compress compacts levels until the sketch is within its total capacity.
+// Compacting a level sorts it and promotes every other value to the next level,
+// where each one stands for twice as many input values.
+func (s *QuantileSketch) compress() {
+	for s.size >= s.totalCapacity() {
+		for h := range s.levels {
+			level := s.levels[h]
+			if len(level) < s.capacity(h) {
+				continue
+			}
+
+			if h+1 == len(s.levels) {
+				s.levels = append(s.levels, nil)
+			}
+
+			slices.Sort(level)
+
+			var (
+				leftover []float64
+				offset   int
+			)
+			if len(level)%2 == 1 {
+				leftover = []float64{level[len(level)-1]}
+				level = level[:len(level)-1]
+			}
+			if s.coin {
+				offset = 1
+			}
+			s.coin = !s.coin
+
+			for i := offset; i < len(level); i += 2 {
+				s.levels[h+1] = append(s.levels[h+1], level[i])
+			}
+			s.levels[h] = append(s.levels[h][:0], leftover...)
+			s.size -= len(level) / 2
+
+			break
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package seqs
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestQuantileSketch(t *testing.T) {
+	const n = 100_000
+
+	var (
+		rng  = rand.New(rand.NewPCG(1, 2))
+		perm = rng.Perm(n)
+		s    = NewQuantileSketch(200)
+	)
+	for _, v := range perm {
+		s.Add(float64(v))
+	}
+	if s.Count() != n {
+		t.Errorf("got count %d, want %d", s.Count(), n)
+	}
+	checkQuantiles(t, s, n)
+}
+
+func TestQuantileSketchMerge(t *testing.T) {
+	const n = 100_000
+
+	var (
+		rng  = rand.New(rand.NewPCG(3, 4))
+		perm = rng.Perm(n)
+		a    = SketchQuantiles(slices.Values(perm[:n/3]), 200)
+		b    = SketchQuantiles(slices.Values(perm[n/3:]), 200)
+	)
+	a.Merge(b)
+	if a.Count() != n {
+		t.Errorf("got count %d, want %d", a.Count(), n)
+	}
+	checkQuantiles(t, a, n)
+}
+
+func TestQuantileSketchEmpty(t *testing.T) {
+	s := NewQuantileSketch(0)
+	if got := s.Quantile(0.5); !math.IsNaN(got) {
+		t.Errorf("got %v, want NaN", got)
+	}
+}
+
+// checkQuantiles checks the quantile estimates of a sketch of a permutation of 0..n-1.
+// The true q-quantile of that is q*n, so the rank error is easy to measure.
+func checkQuantiles(t *testing.T, s *QuantileSketch, n int) {
+	t.Helper()
+
+	for _, q := range []float64{0, 0.01, 0.25, 0.5, 0.75, 0.99, 1} {
+		var (
+			got  = s.Quantile(q)
+			want = q * float64(n)
+		)
+		if rankErr := math.Abs(got-want) / float64(n); rankErr > 0.02 {
+			t.Errorf("quantile %v: got %v, want about %v (rank error %v)", q, got, want, rankErr)
+		}
+	}
+}
+
+func TestQuantileSketchZero(t *testing.T) {
+	const n = 10_000
+
+	var s QuantileSketch
+	for i := range n {
+		s.Add(float64(i))
+	}
+	if s.k != 200 {
+		t.Errorf("got k %d, want the default 200", s.k)
+	}
+	checkQuantiles(t, &s, n)
+
+	var (
+		merged QuantileSketch
+		other  = SketchQuantiles(Limit(Ints(0, 1), n), 50)
+	)
+	merged.Merge(other)
+	if merged.k != 50 {
+		t.Errorf("got k %d after merge, want 50", merged.k)
+	}
+	checkQuantiles(t, &merged, n)
+}
@@ -0,0 +1,160 @@
+package seqs
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"iter"
+)
+
+// Errors reported by [Records].
+var (
+	ErrRecordTooLarge = errors.New("record too large")
+	ErrRecordChecksum = errors.New("record checksum mismatch")
+)
+
+// DefaultMaxRecordSize is the maximum record size used by [Records]
+// when none is given.
+const DefaultMaxRecordSize = 64 << 20
+
+// WriteRecords writes the byte slices in inp to w as a stream of binary records,
+// which can be read back with [Records].
+// Unlike text lines,
+// records may contain any bytes,
+// including newlines.
+//
+// Each record is written as its length,
+// encoded as a uvarint (see [binary.AppendUvarint]),
+// followed by its bytes.
+// If checksum is true,
+// the bytes are followed by their IEEE CRC-32 checksum
+// (see [crc32.ChecksumIEEE]),
+// as four big-endian bytes.
+// The reader must agree with the writer about whether checksums are present.
+//
+// Each record is written to w with a single call to its Write method,
+// so a reader at the other end of a pipe sees whole records as soon as they are written.
+func WriteRecords(w io.Writer, inp iter.Seq[[]byte], checksum bool) error {
+	var (
+		buf []byte
+		n   int
+	)
+
+	for rec := range inp {
+		buf = binary.AppendUvarint(buf[:0], uint64(len(rec)))
+		buf = append(buf, rec...)
+		if checksum {
+			buf = binary.BigEndian.AppendUint32(buf, crc32.ChecksumIEEE(rec))
+		}
+		if _, err := w.Write(buf); err != nil {
+			return fmt.Errorf("writing record %d: %w", n, err)
+		}
+		n++
+	}
+
+	return nil
+}
+
+// Records produces an iterator over the binary records in r,
+// as written by [WriteRecords].
+// Each record is a newly allocated byte slice.
+// The value of checksum must match the one used when writing.
+//
+// If a record's length exceeds maxSize,
+// iteration stops with an error wrapping [ErrRecordTooLarge]
+// (without allocating space for the record).
+// If maxSize is less than 1,
+// [DefaultMaxRecordSize] is used.
+//
+// Input that ends in the middle of a record
+// produces an error wrapping [io.ErrUnexpectedEOF],
+// and a record whose checksum does not match
+// produces an error wrapping [ErrRecordChecksum].
+// Errors report the index of the record and its byte offset in the input.
+//
+// The caller can dereference the returned error pointer to check for errors
+// but only after iteration is done.
+func Records(r io.Reader, maxSize int, checksum bool) (iter.Seq[[]byte], *error) {
+	if maxSize < 1 {
+		maxSize = DefaultMaxRecordSize
+	}
+
+	var err error
+
+	f := func(yield func([]byte) bool) {
+		br, ok := r.(recordReader)
+		if !ok {
+			br = bufio.NewReader(r)
+		}
+
+		var offset int64
+
+		for n := 0; ; n++ {
+			rec, size, err2 := readRecord(br, maxSize, checksum)
+			if errors.Is(err2, io.EOF) {
+				return
+			}
+			if err2 != nil {
+				err = fmt.Errorf("record %d at offset %d: %w", n, offset, err2)
+				return
+			}
+			if !yield(rec) {
+				return
+			}
+			offset += size
+		}
+	}
+
+	return f, &err
+}
+
+type recordReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+// readRecord reads a single record.
+// It returns the record and the number of bytes it occupied in the input.
+// It returns io.EOF only if the input ends cleanly before the record.
+func readRecord(r recordReader, maxSize int, checksum bool) ([]byte, int64, error) {
+	length, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, 0, err
+	}
+	if length > uint64(maxSize) {
+		return nil, 0, fmt.Errorf("%w: length %d exceeds maximum %d", ErrRecordTooLarge, length, maxSize)
+	}
+
+	size := int64(len(binary.AppendUvarint(nil, length))) + int64(length)
+
+	rec := make([]byte, length)
+	if _, err := io.ReadFull(r, rec); err != nil {
+		return nil, 0, fmt.Errorf("reading %d-byte record: %w", length, noEOF(err))
+	}
+
+	if checksum {
+		var sum [4]byte
+		if _, err := io.ReadFull(r, sum[:]); err != nil {
+			return nil, 0, fmt.Errorf("reading checksum: %w", noEOF(err))
+		}
+		size += 4
+
+		want, got := binary.BigEndian.Uint32(sum[:]), crc32.ChecksumIEEE(rec)
+		if got != want {
+			return nil, 0, fmt.Errorf("%w: got %08x, want %08x", ErrRecordChecksum, got, want)
+		}
+	}
+
+	return rec, size, nil
+}
+
+// noEOF converts io.EOF, meaning that the input ended in the middle of something, to io.ErrUnexpectedEOF.
+func noEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,108 @@
+package seqs
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRecords(t *testing.T) {
+	recs := [][]byte{
+		[]byte("hello"),
+		{},
+		[]byte("line one\nline two\n"),
+		bytes.Repeat([]byte{0, 1, 2, 255}, 100),
+	}
+
+	for _, checksum := range []bool{false, true} {
+		buf := new(bytes.Buffer)
+		if err := WriteRecords(buf, slices.Values(recs), checksum); err != nil {
+			t.Fatal(err)
+		}
+
+		got, errptr := Records(iotest.OneByteReader(buf), 0, checksum)
+		if diff := cmp.Diff(recs, slices.Collect(got)); diff != "" {
+			t.Errorf("checksum=%t: mismatch (-want +got):\n%s", checksum, diff)
+		}
+		if err := *errptr; err != nil {
+			t.Fatalf("checksum=%t: %s", checksum, err)
+		}
+	}
+}
+
+func TestRecordsErrors(t *testing.T) {
+	encode := func(checksum bool, recs ...string) []byte {
+		buf := new(bytes.Buffer)
+		if err := WriteRecords(buf, Map(slices.Values(recs), func(s string) []byte { return []byte(s) }), checksum); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+
+	good := encode(true, "abc", "defgh")
+
+	corrupt := slices.Clone(good)
+	corrupt[len(corrupt)-6] ^= 1 // a byte of the second record
+
+	cases := []struct {
+		name     string
+		input    []byte
+		maxSize  int
+		wantN    int
+		wantErr  error
+		wantText string
+	}{{
+		name:     "truncated length",
+		input:    append(encode(true, "abc"), 0x80),
+		wantN:    1,
+		wantErr:  io.ErrUnexpectedEOF,
+		wantText: "record 1 at offset 8",
+	}, {
+		name:     "truncated data",
+		input:    good[:len(good)-6],
+		wantN:    1,
+		wantErr:  io.ErrUnexpectedEOF,
+		wantText: "record 1 at offset 8: reading 5-byte record",
+	}, {
+		name:     "truncated checksum",
+		input:    good[:len(good)-2],
+		wantN:    1,
+		wantErr:  io.ErrUnexpectedEOF,
+		wantText: "reading checksum",
+	}, {
+		name:     "checksum",
+		input:    corrupt,
+		wantN:    1,
+		wantErr:  ErrRecordChecksum,
+		wantText: "record 1 at offset 8",
+	}, {
+		name:     "too large",
+		input:    good,
+		maxSize:  4,
+		wantN:    1,
+		wantErr:  ErrRecordTooLarge,
+		wantText: "length 5 exceeds maximum 4",
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			recs, errptr := Records(bytes.NewReader(tc.input), tc.maxSize, true)
+			if got := slices.Collect(recs); len(got) != tc.wantN {
+				t.Errorf("got %d records, want %d", len(got), tc.wantN)
+			}
+			err := *errptr
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("got error %v, want %v", err, tc.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), tc.wantText) {
+				t.Errorf("error %q does not contain %q", err, tc.wantText)
+			}
+		})
+	}
+}
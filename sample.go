@@ -0,0 +1,106 @@
+package seqs
+
+import (
+	"cmp"
+	"iter"
+	"math"
+	"math/rand/v2"
+)
+
+// Sample returns a uniform random sample of k elements of inp,
+// or all of inp if it has k elements or fewer,
+// using rng as the source of randomness.
+// The elements of the sample are in no particular order.
+//
+// Sample uses reservoir sampling ("Algorithm L" by Kim-Hung Li),
+// so it holds only k elements in memory at a time,
+// and its use of rng grows only logarithmically with the length of inp.
+// It consumes the entire input sequence.
+// Beware of infinite input!
+func Sample[T any](inp iter.Seq[T], k int, rng *rand.Rand) []T {
+	if k < 1 {
+		return nil
+	}
+
+	var (
+		reservoir = make([]T, 0, k)
+		w         float64 // the "W" of Algorithm L
+		next      int     // index of the next element of inp to go into the reservoir
+		i         int
+	)
+
+	// Returns a random number in (0, 1].
+	random := func() float64 {
+		return 1 - rng.Float64()
+	}
+
+	advance := func() {
+		w *= math.Exp(math.Log(random()) / float64(k))
+		next += int(math.Floor(math.Log(random())/math.Log(1-w))) + 1
+	}
+
+	for val := range inp {
+		switch {
+		case i < k:
+			reservoir = append(reservoir, val)
+			if i == k-1 {
+				w = 1
+				next = i
+				advance()
+			}
+
+		case i == next:
+			reservoir[rng.IntN(k)] = val
+			advance()
+		}
+		i++
+	}
+
+	return reservoir
+}
+
+// SampleWeighted returns a weighted random sample of k elements of inp,
+// or all the elements with positive weight if there are k or fewer,
+// using rng as the source of randomness.
+// The function weight gives the weight of each element.
+// The probability of an element being included is proportional to its weight
+// (relative to those of the elements not yet chosen).
+// Elements with a weight of zero or less are never included.
+// The elements of the sample are in no particular order.
+//
+// SampleWeighted uses the A-ES algorithm of Efraimidis and Spirakis,
+// holding only k elements in memory at a time.
+// It consumes the entire input sequence.
+// Beware of infinite input!
+func SampleWeighted[T any](inp iter.Seq[T], k int, weight func(T) float64, rng *rand.Rand) []T {
+	// Each element gets the key u^(1/w) for random u in (0, 1],
+	// and the sample is the k elements with the largest keys.
+	// Comparing log(u)/w is equivalent and more numerically stable.
+	h := newTopKHeap(k, func(a, b Pair[float64, T]) int {
+		return cmp.Compare(a.X, b.X)
+	})
+	for val := range inp {
+		w := weight(val)
+		if w <= 0 {
+			continue
+		}
+		key := math.Log(1-rng.Float64()) / w
+		h.add(Pair[float64, T]{X: key, Y: val})
+	}
+
+	result := make([]T, 0, len(h.items))
+	for _, p := range h.items {
+		result = append(result, p.Y)
+	}
+	return result
+}
+
+// SampleRate produces an iterator over a random subset of the elements of inp,
+// including each one independently with probability p
+// (a "Bernoulli sample"),
+// using rng as the source of randomness.
+func SampleRate[T any](inp iter.Seq[T], p float64, rng *rand.Rand) iter.Seq[T] {
+	return Filter(inp, func(T) bool {
+		return rng.Float64() < p
+	})
+}
@@ -0,0 +1,114 @@
+package seqs
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestSample(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	t.Run("short", func(t *testing.T) {
+		got := Sample(From(1, 2, 3), 5, rng)
+		if !slices.Equal(got, []int{1, 2, 3}) {
+			t.Errorf("got %v, want [1 2 3]", got)
+		}
+	})
+
+	t.Run("reproducible", func(t *testing.T) {
+		var (
+			s1 = Sample(Limit(Ints(0, 1), 1000), 10, rand.New(rand.NewPCG(3, 4)))
+			s2 = Sample(Limit(Ints(0, 1), 1000), 10, rand.New(rand.NewPCG(3, 4)))
+		)
+		if !slices.Equal(s1, s2) {
+			t.Errorf("same seed gave %v and %v", s1, s2)
+		}
+	})
+
+	t.Run("uniform", func(t *testing.T) {
+		const (
+			n      = 20
+			k      = 5
+			trials = 20000
+		)
+
+		counts := make([]int, n)
+		for i := 0; i < trials; i++ {
+			s := Sample(Limit(Ints(0, 1), n), k, rng)
+			if len(s) != k {
+				t.Fatalf("got sample of size %d, want %d", len(s), k)
+			}
+			seen := make(map[int]bool)
+			for _, x := range s {
+				if seen[x] {
+					t.Fatalf("duplicate %d in sample %v", x, s)
+				}
+				seen[x] = true
+				counts[x]++
+			}
+		}
+
+		want := float64(trials * k / n)
+		for x, c := range counts {
+			if math.Abs(float64(c)-want) > 0.1*want {
+				t.Errorf("element %d chosen %d times, want about %.0f", x, c, want)
+			}
+		}
+	})
+}
+
+func TestSampleWeighted(t *testing.T) {
+	const trials = 20000
+
+	var (
+		rng    = rand.New(rand.NewPCG(1, 2))
+		counts = make(map[string]int)
+		inp    = slices.Values([]string{"a", "b", "c", "zero"})
+		weight = func(s string) float64 {
+			switch s {
+			case "a":
+				return 1
+			case "b":
+				return 3
+			case "c":
+				return 6
+			}
+			return 0
+		}
+	)
+	for i := 0; i < trials; i++ {
+		s := SampleWeighted(inp, 1, weight, rng)
+		if len(s) != 1 {
+			t.Fatalf("got sample %v, want one element", s)
+		}
+		counts[s[0]]++
+	}
+
+	if counts["zero"] != 0 {
+		t.Errorf("zero-weight element chosen %d times", counts["zero"])
+	}
+	for s, frac := range map[string]float64{"a": 0.1, "b": 0.3, "c": 0.6} {
+		want := frac * trials
+		if got := float64(counts[s]); math.Abs(got-want) > 0.1*want {
+			t.Errorf("%s chosen %.0f times, want about %.0f", s, got, want)
+		}
+	}
+
+	got := SampleWeighted(inp, 5, weight, rng)
+	slices.Sort(got)
+	if !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("got %v, want [a b c]", got)
+	}
+}
+
+func TestSampleRate(t *testing.T) {
+	var (
+		rng = rand.New(rand.NewPCG(1, 2))
+		n   = Drain(SampleRate(Limit(Ints(0, 1), 10000), 0.25, rng))
+	)
+	if n < 2300 || n > 2700 {
+		t.Errorf("got %d elements, want about 2500", n)
+	}
+}
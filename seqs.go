@@ -63,6 +63,18 @@ func Enumerate[T any](inp iter.Seq[T]) iter.Seq2[int, T] {
 	}
 }
 
+// KeyBy changes an [iter.Seq] to an [iter.Seq2] of (key, val) pairs,
+// where each key is computed from its value by the function f.
+func KeyBy[T, K any](inp iter.Seq[T], f func(T) K) iter.Seq2[K, T] {
+	return func(yield func(K, T) bool) {
+		for x := range inp {
+			if !yield(f(x), x) {
+				return
+			}
+		}
+	}
+}
+
 // FromPairs changes an [iter.Seq] of [Pair]s to an [iter.Seq2].
 func FromPairs[T, U any](inp iter.Seq[Pair[T, U]]) iter.Seq2[T, U] {
 	return func(yield func(T, U) bool) {
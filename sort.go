@@ -0,0 +1,216 @@
+package seqs
+
+import (
+	"bufio"
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"slices"
+)
+
+// ExternalSort produces a sorted copy of inp,
+// which may be too large to hold in memory.
+// It is equivalent to calling [ExternalSortFunc] with [cmp.Compare].
+func ExternalSort[T cmp.Ordered](inp iter.Seq[T], runSize int, codec Codec[T]) (iter.Seq[T], *error) {
+	return ExternalSortFunc(inp, runSize, cmp.Compare[T], codec)
+}
+
+// ExternalSortFunc produces a copy of inp sorted by the comparison function cmp.
+// It holds at most runSize elements of inp in memory at once
+// (plus one pending element per run during each merge).
+//
+// ExternalSortFunc reads inp in runs of runSize elements,
+// sorts each run,
+// and writes it to a temporary file
+// (in the directory given by [os.TempDir])
+// using the given codec,
+// or [GobCodec] if codec is nil.
+// It then merges the runs with [MergeAllFunc].
+// The final run is not written out but merged directly from memory,
+// so if inp has no more than runSize elements,
+// no temporary files are used.
+//
+// To limit the number of files open at once,
+// at most 64 runs are merged together.
+// When there are more runs than that,
+// they are merged in groups into longer runs,
+// themselves written to temporary files,
+// until few enough remain for a final merge.
+//
+// The sort is stable:
+// equal elements appear in the output in the order they appear in inp.
+//
+// The temporary files are removed when iteration ends,
+// including when the caller stops early.
+// If runSize is less than 1, a default of 100,000 is used.
+//
+// The function cmp must return a negative value if its arguments are properly ordered,
+// a positive value if they are reversed,
+// and zero if they are equal.
+//
+// The caller can dereference the returned error pointer to check for errors
+// (such as failures writing or reading the temporary files),
+// but only after iteration is done.
+// If there is an error, the output is incomplete.
+func ExternalSortFunc[T any](inp iter.Seq[T], runSize int, cmp func(T, T) int, codec Codec[T]) (iter.Seq[T], *error) {
+	return externalSortFunc(inp, runSize, cmp, codec, externalSortFanIn)
+}
+
+// externalSortFanIn is the maximum number of runs merged at once by [ExternalSortFunc].
+const externalSortFanIn = 64
+
+func externalSortFunc[T any](inp iter.Seq[T], runSize int, cmp func(T, T) int, codec Codec[T], fanIn int) (iter.Seq[T], *error) {
+	if runSize < 1 {
+		runSize = 100_000
+	}
+	if codec == nil {
+		codec = GobCodec[T]{}
+	}
+	fanIn = max(fanIn, 2)
+
+	var err error
+
+	f := func(yield func(T) bool) {
+		var files []string // all temporary files not yet removed
+
+		defer func() {
+			for _, name := range files {
+				err = errors.Join(err, os.Remove(name))
+			}
+		}()
+
+		// spill writes a sorted run to a new temporary file and adds it to files.
+		spill := func(run iter.Seq[T]) (string, bool) {
+			name, spillErr := spillRun(run, codec)
+			if name != "" {
+				files = append(files, name)
+			}
+			if spillErr != nil {
+				err = spillErr
+				return "", false
+			}
+			return name, err == nil // err may have been set by readRun during a merge
+		}
+
+		var (
+			level []string // the spilled runs, in input order
+			run   []T
+		)
+
+		for val := range inp {
+			if len(run) == runSize {
+				// Only now that there is another element is it known that this is not the final run.
+				slices.SortStableFunc(run, cmp)
+				name, ok := spill(slices.Values(run))
+				if !ok {
+					return
+				}
+				level = append(level, name)
+				run = run[:0]
+			}
+			run = append(run, val)
+		}
+		slices.SortStableFunc(run, cmp)
+
+		// Merge consecutive groups of runs
+		// (which keeps the sort stable)
+		// until they can be merged together with the final run in a single pass.
+		for len(level) >= fanIn {
+			var next []string
+			for group := range slices.Chunk(level, fanIn) {
+				if len(group) == 1 {
+					next = append(next, group[0])
+					continue
+				}
+
+				runs := make([]iter.Seq[T], 0, len(group))
+				for _, name := range group {
+					runs = append(runs, readRun(name, codec, &err))
+				}
+				name, ok := spill(MergeAllFunc(runs, cmp))
+				if !ok {
+					return
+				}
+				next = append(next, name)
+
+				// Try to remove every file in the group,
+				// even after a failure.
+				for _, name := range group {
+					err = errors.Join(err, os.Remove(name))
+					files = slices.DeleteFunc(files, func(f string) bool { return f == name })
+				}
+				if err != nil {
+					return
+				}
+			}
+			level = next
+		}
+
+		runs := make([]iter.Seq[T], 0, len(level)+1)
+		for _, name := range level {
+			runs = append(runs, readRun(name, codec, &err))
+		}
+		runs = append(runs, slices.Values(run))
+
+		for val := range MergeAllFunc(runs, cmp) {
+			if err != nil || !yield(val) {
+				return
+			}
+		}
+	}
+
+	return f, &err
+}
+
+// spillRun writes run to a new temporary file and returns its name.
+// The file is closed,
+// so that the number of open files does not grow with the number of runs.
+// If the file was created, spillRun returns its name even if there is an error,
+// so the caller can clean it up.
+func spillRun[T any](run iter.Seq[T], codec Codec[T]) (string, error) {
+	f, err := os.CreateTemp("", "seqs-sort-*")
+	if err != nil {
+		return "", fmt.Errorf("creating temporary file: %w", err)
+	}
+
+	err = EncodeStream(f, run, codec)
+	err = errors.Join(err, f.Close())
+	if err != nil {
+		return f.Name(), fmt.Errorf("writing %s: %w", f.Name(), err)
+	}
+
+	return f.Name(), nil
+}
+
+// readRun produces an iterator over the values in a file written by spillRun.
+// The file is open only while the iterator is running.
+// If there is an error, it is stored in *errptr and the iterator stops.
+func readRun[T any](name string, codec Codec[T], errptr *error) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		f, err := os.Open(name)
+		if err != nil {
+			*errptr = err
+			return
+		}
+		defer f.Close()
+
+		dec := codec.NewDecoder(bufio.NewReader(f))
+		for {
+			var val T
+			err := dec.Decode(&val)
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				*errptr = fmt.Errorf("decoding value from %s: %w", name, err)
+				return
+			}
+			if !yield(val) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package seqs
+
+import (
+	"cmp"
+	"io"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"testing"
+)
+
+func TestExternalSort(t *testing.T) {
+	tmpdir := t.TempDir()
+	t.Setenv("TMPDIR", tmpdir)
+
+	checkClean := func(t *testing.T) {
+		t.Helper()
+		entries, err := os.ReadDir(tmpdir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) > 0 {
+			t.Errorf("%d temporary files remain", len(entries))
+		}
+	}
+
+	var (
+		rng = rand.New(rand.NewPCG(1, 2))
+		inp = make([]int, 1000)
+	)
+	for i := range inp {
+		inp[i] = rng.IntN(500)
+	}
+	want := slices.Sorted(slices.Values(inp))
+
+	for _, runSize := range []int{1, 7, 100, 1000, 5000} {
+		t.Run("", func(t *testing.T) {
+			sorted, errptr := ExternalSort(slices.Values(inp), runSize, nil)
+			got := slices.Collect(sorted)
+			if *errptr != nil {
+				t.Fatal(*errptr)
+			}
+			if !slices.Equal(got, want) {
+				t.Errorf("runSize %d: output not sorted", runSize)
+			}
+			checkClean(t)
+		})
+	}
+
+	t.Run("early_exit", func(t *testing.T) {
+		sorted, errptr := ExternalSort(slices.Values(inp), 100, nil)
+		got := slices.Collect(Limit(sorted, 10))
+		if *errptr != nil {
+			t.Fatal(*errptr)
+		}
+		if !slices.Equal(got, want[:10]) {
+			t.Errorf("got %v, want %v", got, want[:10])
+		}
+		checkClean(t)
+	})
+
+	t.Run("stable", func(t *testing.T) {
+		type rec struct {
+			Key, Seq int
+		}
+
+		var recs []rec
+		for i, n := range inp {
+			recs = append(recs, rec{Key: n % 10, Seq: i})
+		}
+
+		sorted, errptr := ExternalSortFunc(slices.Values(recs), 64, func(a, b rec) int {
+			return cmp.Compare(a.Key, b.Key)
+		}, jsonCodec[rec]{})
+		got := slices.Collect(sorted)
+		if *errptr != nil {
+			t.Fatal(*errptr)
+		}
+
+		want := slices.Clone(recs)
+		slices.SortStableFunc(want, func(a, b rec) int {
+			return cmp.Compare(a.Key, b.Key)
+		})
+		if !slices.Equal(got, want) {
+			t.Error("output not stably sorted")
+		}
+		checkClean(t)
+	})
+
+	t.Run("multipass", func(t *testing.T) {
+		type rec struct {
+			Key, Seq int
+		}
+
+		var recs []rec
+		for i, n := range inp {
+			recs = append(recs, rec{Key: n % 10, Seq: i})
+		}
+		keyCmp := func(a, b rec) int {
+			return cmp.Compare(a.Key, b.Key)
+		}
+
+		// 1000 elements in runs of 7 is 143 runs,
+		// which with a fan-in of 3 takes several merge passes.
+		for _, fanIn := range []int{2, 3, 10} {
+			sorted, errptr := externalSortFunc(slices.Values(recs), 7, keyCmp, nil, fanIn)
+			got := slices.Collect(sorted)
+			if *errptr != nil {
+				t.Fatal(*errptr)
+			}
+
+			want := slices.Clone(recs)
+			slices.SortStableFunc(want, keyCmp)
+			if !slices.Equal(got, want) {
+				t.Errorf("fanIn %d: output not stably sorted", fanIn)
+			}
+			checkClean(t)
+		}
+	})
+
+	t.Run("no_spill", func(t *testing.T) {
+		// Input that fits in a single run is never written out.
+		sorted, errptr := ExternalSort(slices.Values(inp), len(inp), failingCodec[int]{t: t})
+		got := slices.Collect(sorted)
+		if *errptr != nil {
+			t.Fatal(*errptr)
+		}
+		if !slices.Equal(got, want) {
+			t.Error("output not sorted")
+		}
+	})
+}
+
+// failingCodec is a [Codec] that fails the test if it is used.
+type failingCodec[T any] struct {
+	t *testing.T
+}
+
+func (c failingCodec[T]) NewEncoder(io.Writer) Encoder[T] {
+	c.t.Fatal("unexpected call to NewEncoder")
+	return nil
+}
+
+func (c failingCodec[T]) NewDecoder(io.Reader) Decoder[T] {
+	c.t.Fatal("unexpected call to NewDecoder")
+	return nil
+}
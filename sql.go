@@ -3,11 +3,16 @@ package seqs
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"fmt"
 	"iter"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 // QueryerContext is a minimal interface satisfied by *sql.DB and *sql.Tx
@@ -20,15 +25,38 @@ type QueryerContext interface {
 // and returns the results as an iterator over items of type T.
 //
 // If the query produces a single value per row,
-// T may be any scalar type (bool, int, float, string)
+// T may be any scalar type (bool, int, float, string, []byte, [time.Time])
 // into which each row's value can be scanned.
-// T may also be [sql.Null] for querying a single nullable column.
+// T may also be any type whose pointer implements [sql.Scanner],
+// such as [sql.Null] or [sql.NullString],
+// or any pointer type,
+// for querying a single nullable column
+// (a NULL value produces a nil pointer).
 //
-// Otherwise T must be a struct type whose fields have the same types,
-// in the same order,
-// as the values being queried.
+// Otherwise T must be a struct type.
 // The values produced by the iterator will be instances of that struct type,
 // with fields populated by the queried values.
+// Each column in the query result is matched to a struct field by name.
+// A field's name for this purpose is given by its `db` struct tag,
+// as in:
+//
+//	type employee struct {
+//	  Name   string `db:"full_name"`
+//	  Salary int    `db:"salary"`
+//	  Notes  string `db:"-"` // never populated from a column
+//	}
+//
+// Fields without a `db` tag use the Go field name.
+// A column that matches no field name exactly is matched case-insensitively.
+// Unexported fields and fields tagged `db:"-"` are skipped.
+// The fields of embedded (anonymous) struct fields
+// are treated as fields of the outer struct,
+// unless the embedded field has a `db` tag giving it a name.
+// Fields with no matching column are left as zero values.
+// It is an error for a column to have no matching field
+// (as with an unnamed expression like "salary + 1",
+// which can be given a name with AS),
+// or for two columns to match the same field.
 //
 // The caller may check for errors by dereferencing the returned error pointer,
 // but only after the iterator is fully consumed.
@@ -67,34 +95,513 @@ func Prepared[T any](ctx context.Context, stmt *sql.Stmt, args ...any) (iter.Seq
 	return f, &err
 }
 
+// SQLRecord is a row from a query result whose shape is not known in advance.
+// See [SQLRecords].
+type SQLRecord struct {
+	// Columns are the column names of the query result.
+	// This slice is shared among all the records from a single query
+	// and must not be modified.
+	Columns []string
+
+	// Values are the values of the row,
+	// one for each column.
+	Values []any
+}
+
+// All produces an iterator over the column-name/value pairs in the record,
+// in column order.
+func (r SQLRecord) All() iter.Seq2[string, any] {
+	return ZipVals(slices.Values(r.Columns), slices.Values(r.Values))
+}
+
+// Map produces a map from column names to values.
+// If the query produced more than one column with the same name,
+// only the last one appears in the map.
+func (r SQLRecord) Map() map[string]any {
+	m := make(map[string]any, len(r.Columns))
+	for col, val := range r.All() {
+		m[col] = val
+	}
+	return m
+}
+
+// SQLRecords performs a query against db
+// and returns the results as an iterator over [SQLRecord]s.
+// This is useful when there is no Go type corresponding to the query's result,
+// as with ad hoc queries.
+//
+// The Go type of each value is chosen based on the column's [sql.ColumnType.ScanType]
+// as reported by the database driver,
+// falling back to the driver's own value type
+// when the driver does not report a specific type.
+// NULL values are produced as nil.
+//
+// The caller may check for errors by dereferencing the returned error pointer,
+// but only after the iterator is fully consumed.
+func SQLRecords(ctx context.Context, db QueryerContext, query string, args ...any) (iter.Seq[SQLRecord], *error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return Empty[SQLRecord], &err
+	}
+
+	f := func(yield func(SQLRecord) bool) {
+		err = sqlHelperRecord(ctx, rows, yield)
+		err2 := rows.Close()
+		err = errors.Join(err, err2)
+	}
+
+	return f, &err
+}
+
+func sqlHelperRecord(ctx context.Context, rows *sql.Rows, yield func(SQLRecord) bool) error {
+	cols, err := rows.Columns()
+	if err != nil {
+		return fmt.Errorf("getting columns: %w", err)
+	}
+	colTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return fmt.Errorf("getting column types: %w", err)
+	}
+
+	dests := make([]sqlRecordDest, 0, len(colTypes))
+	for _, ct := range colTypes {
+		dests = append(dests, newSQLRecordDest(ct))
+	}
+
+	return sqlScanRows(ctx, rows, yield, func() (SQLRecord, error) {
+		ptrs := make([]any, 0, len(dests))
+		for _, d := range dests {
+			ptrs = append(ptrs, reflect.New(d.typ).Interface())
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return SQLRecord{}, err
+		}
+
+		vals := make([]any, 0, len(dests))
+		for i, d := range dests {
+			val, err := d.value(reflect.ValueOf(ptrs[i]).Elem())
+			if err != nil {
+				return SQLRecord{}, fmt.Errorf("column %s: %w", cols[i], err)
+			}
+			vals = append(vals, val)
+		}
+
+		return SQLRecord{Columns: cols, Values: vals}, nil
+	})
+}
+
+// sqlRecordDest describes how to scan a single column of a [SQLRecord].
+type sqlRecordDest struct {
+	typ   reflect.Type                     // the type to scan into (via a pointer)
+	value func(reflect.Value) (any, error) // converts the scanned value to the record value
+}
+
+var (
+	rawBytesType = reflect.TypeFor[sql.RawBytes]()
+	valuerType   = reflect.TypeFor[driver.Valuer]()
+)
+
+func newSQLRecordDest(ct *sql.ColumnType) sqlRecordDest {
+	st := ct.ScanType()
+
+	switch {
+	case st == nil || st.Kind() == reflect.Interface || (st.Kind() == reflect.Pointer && st.Elem().Kind() == reflect.Interface):
+		// Let the driver choose.
+		return sqlRecordDest{typ: reflect.TypeFor[any](), value: sqlRecordPlain}
+
+	case st == rawBytesType:
+		// RawBytes is only valid until the next call to Scan.
+		st = reflect.TypeFor[[]byte]()
+
+	case st.Implements(valuerType) && reflect.PointerTo(st).Implements(scannerType):
+		// A type like sql.NullInt64, which knows how to produce its own value.
+		return sqlRecordDest{
+			typ: st,
+			value: func(v reflect.Value) (any, error) {
+				return v.Interface().(driver.Valuer).Value()
+			},
+		}
+	}
+
+	if nullable, ok := ct.Nullable(); ok && !nullable {
+		return sqlRecordDest{typ: st, value: sqlRecordPlain}
+	}
+
+	// The column is (or may be) nullable.
+	// Scan into a pointer, which will be nil for NULL.
+	return sqlRecordDest{
+		typ: reflect.PointerTo(st),
+		value: func(v reflect.Value) (any, error) {
+			if v.IsNil() {
+				return nil, nil
+			}
+			return v.Elem().Interface(), nil
+		},
+	}
+}
+
+func sqlRecordPlain(v reflect.Value) (any, error) {
+	return v.Interface(), nil
+}
+
+// SQLKeyset is like [SQL] but fetches the results of the query one page at a time,
+// using keyset pagination.
+// This avoids holding a single [sql.Rows] open for the duration of a long iteration.
+//
+// The key columns must be among the columns produced by query,
+// and must uniquely identify each row.
+// For each page,
+// SQLKeyset issues a query of the form
+//
+//	SELECT * FROM (query) AS q WHERE (k1, k2, ...) > (?, ?, ...) ORDER BY k1, k2, ... LIMIT pageSize
+//
+// where the placeholders are filled from the key values of the last row of the previous page.
+// (The first page omits the WHERE clause.)
+// The given args are passed for any placeholders in query,
+// before the key values.
+// The placeholders for the key values are produced by ph,
+// numbered after those for args,
+// so that a query using PostgreSQL-style placeholders
+// (which should use [DollarPlaceholder])
+// like "SELECT ... WHERE x > $1" gets a WHERE clause with $2, $3, etc.
+// If ph is nil, [QuestionPlaceholder] is used.
+// Comparisons of multi-column keys rely on SQL row values,
+// which most databases support.
+//
+// If T is a struct type,
+// the key values are taken from the fields matching the key columns,
+// according to the rules described for [SQL].
+// Otherwise there must be a single key column,
+// and the key value is the value of T itself.
+//
+// If pageSize is less than 1, a default of 1000 is used.
+// Each page's rows are closed before the next page is fetched.
+//
+// The caller may check for errors by dereferencing the returned error pointer,
+// but only after the iterator is fully consumed.
+func SQLKeyset[T any](ctx context.Context, db QueryerContext, query string, keyCols []string, pageSize int, ph Placeholder, args ...any) (iter.Seq[T], *error) {
+	var err error
+
+	if len(keyCols) == 0 {
+		err = errors.New("no key columns")
+		return Empty[T], &err
+	}
+
+	keyFn, err := sqlKeyFunc(reflect.TypeFor[T](), keyCols)
+	if err != nil {
+		return Empty[T], &err
+	}
+
+	if pageSize < 1 {
+		pageSize = 1000
+	}
+	if ph == nil {
+		ph = QuestionPlaceholder
+	}
+
+	var (
+		keyList  = strings.Join(keyCols, ", ")
+		keyMarks = placeholders(ph, len(args)+1, len(keyCols))
+		first    = fmt.Sprintf("SELECT * FROM (%s) AS q ORDER BY %s LIMIT %d", query, keyList, pageSize)
+		rest     = fmt.Sprintf("SELECT * FROM (%s) AS q WHERE (%s) > (%s) ORDER BY %s LIMIT %d", query, keyList, keyMarks, keyList, pageSize)
+	)
+
+	f := func(yield func(T) bool) {
+		var (
+			q       = first
+			qargs   = args
+			stopped bool
+		)
+
+		for {
+			var (
+				last T
+				n    int
+			)
+
+			rows, qerr := db.QueryContext(ctx, q, qargs...)
+			if qerr != nil {
+				err = qerr
+				return
+			}
+
+			err = sqlHelper(ctx, rows, func(val T) bool {
+				last = val
+				n++
+				if !yield(val) {
+					stopped = true
+					return false
+				}
+				return true
+			})
+			err = errors.Join(err, rows.Close())
+
+			if err != nil || stopped || n < pageSize {
+				return
+			}
+
+			q = rest
+			qargs = append(slices.Clip(args), keyFn(reflect.ValueOf(last))...)
+		}
+	}
+
+	return f, &err
+}
+
+// sqlKeyFunc produces a function for extracting the values of the given key columns from a value of type tt.
+func sqlKeyFunc(tt reflect.Type, keyCols []string) (func(reflect.Value) []any, error) {
+	if isSqlScalar(tt) || tt.Kind() != reflect.Struct {
+		if len(keyCols) != 1 {
+			return nil, fmt.Errorf("%d key columns for non-struct type %s", len(keyCols), tt)
+		}
+		return func(v reflect.Value) []any {
+			return []any{v.Interface()}
+		}, nil
+	}
+
+	var (
+		plan    = sqlPlanFor(tt)
+		indexes = make([][]int, 0, len(keyCols))
+	)
+	for _, col := range keyCols {
+		i, ok := plan.byName[col]
+		if !ok {
+			i, ok = plan.byFold[strings.ToLower(col)]
+		}
+		if !ok {
+			return nil, fmt.Errorf("no field in %s for key column %s", tt, col)
+		}
+		indexes = append(indexes, plan.fields[i].index)
+	}
+
+	return func(v reflect.Value) []any {
+		result := make([]any, 0, len(indexes))
+		for _, index := range indexes {
+			result = append(result, sqlFieldValue(v, index))
+		}
+		return result
+	}, nil
+}
+
+// ExecerContext is a minimal interface satisfied by *sql.DB and *sql.Tx
+// (from database/sql).
+type ExecerContext interface {
+	ExecContext(context.Context, string, ...any) (sql.Result, error)
+}
+
+// Placeholder produces the placeholder for the nth argument of a SQL statement,
+// counting from 1.
+// Different database drivers use different placeholder syntax.
+// See [QuestionPlaceholder] and [DollarPlaceholder].
+type Placeholder func(n int) string
+
+// QuestionPlaceholder is a [Placeholder] that produces ? for every argument,
+// as used by MySQL and SQLite drivers.
+func QuestionPlaceholder(int) string {
+	return "?"
+}
+
+// DollarPlaceholder is a [Placeholder] that produces $1, $2, etc.,
+// as used by PostgreSQL drivers.
+func DollarPlaceholder(n int) string {
+	return "$" + strconv.Itoa(n)
+}
+
+// placeholders produces a comma-separated list of count placeholders,
+// starting with argument number first.
+func placeholders(ph Placeholder, first, count int) string {
+	marks := make([]string, 0, count)
+	for i := range count {
+		marks = append(marks, ph(first+i))
+	}
+	return strings.Join(marks, ", ")
+}
+
+// TxBeginner is a minimal interface satisfied by *sql.DB
+// (from database/sql).
+type TxBeginner interface {
+	BeginTx(context.Context, *sql.TxOptions) (*sql.Tx, error)
+}
+
+// InsertSQLOptions are options for [InsertSQL].
+// A nil *InsertSQLOptions is the same as a pointer to the zero value.
+type InsertSQLOptions struct {
+	// BatchSize is the maximum number of rows inserted by each statement.
+	// If it is less than 1, a default of 100 is used.
+	// (Note that databases limit the number of placeholders in a statement,
+	// which limits the batch size for structs with many fields.)
+	BatchSize int
+
+	// Placeholder produces the placeholders for values in the generated statements.
+	// If it is nil, [QuestionPlaceholder] is used.
+	Placeholder Placeholder
+
+	// Atomic, if true,
+	// causes InsertSQL to perform the whole insertion in a single transaction,
+	// which it commits if all rows are inserted
+	// and rolls back otherwise.
+	// The db argument to InsertSQL must then also implement [TxBeginner],
+	// as *sql.DB does.
+	// To include the insertion in a larger transaction,
+	// leave this false and pass a *sql.Tx as db instead.
+	Atomic bool
+}
+
+// InsertSQL consumes an iterator of structs of type T
+// and inserts a row into the given table for each one.
+// It returns the number of rows inserted and the first error encountered, if any.
+// It is an error for T to be a type that [SQL] treats as a single column,
+// even if it is a struct type such as [time.Time].
+//
+// Column names are derived from the fields of T
+// by the same rules that [SQL] uses for matching columns to fields.
+// The table and column names are used in the generated statements as-is, without quoting.
+//
+// Rows are inserted in batches,
+// each with a single multi-row INSERT statement.
+// See [InsertSQLOptions] for controlling the batch size,
+// the syntax of placeholders in the statements,
+// and whether the insertion is done in a transaction.
+// A nil opts uses the defaults.
+//
+// Unless the insertion is atomic,
+// batches preceding an error remain inserted,
+// and are included in the returned count.
+func InsertSQL[T any](ctx context.Context, db ExecerContext, table string, inp iter.Seq[T], opts *InsertSQLOptions) (int, error) {
+	if opts == nil {
+		opts = new(InsertSQLOptions)
+	}
+
+	if !opts.Atomic {
+		return insertSQL(ctx, db, table, inp, opts)
+	}
+
+	beginner, ok := db.(TxBeginner)
+	if !ok {
+		return 0, fmt.Errorf("atomic insert requires a TxBeginner, but %T is not one", db)
+	}
+	tx, err := beginner.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("beginning transaction: %w", err)
+	}
+
+	n, err := insertSQL(ctx, tx, table, inp, opts)
+	if err != nil {
+		return 0, errors.Join(err, tx.Rollback())
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("committing transaction: %w", err)
+	}
+	return n, nil
+}
+
+func insertSQL[T any](ctx context.Context, db ExecerContext, table string, inp iter.Seq[T], opts *InsertSQLOptions) (int, error) {
+	tt := reflect.TypeFor[T]()
+	if tt.Kind() != reflect.Struct || isSqlScalar(tt) {
+		return 0, sqlInsertKindError{typ: tt}
+	}
+
+	batchSize := opts.BatchSize
+	if batchSize < 1 {
+		batchSize = 100
+	}
+	ph := opts.Placeholder
+	if ph == nil {
+		ph = QuestionPlaceholder
+	}
+
+	fields := sqlPlanFor(tt).columns()
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("no columns in %s", tt)
+	}
+
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		names = append(names, f.name)
+	}
+	prefix := fmt.Sprintf("INSERT INTO %s (%s) VALUES ", table, strings.Join(names, ", "))
+
+	var n int
+
+	for page := range Pages(inp, batchSize) {
+		var (
+			query strings.Builder
+			args  = make([]any, 0, len(page)*len(fields))
+		)
+
+		query.WriteString(prefix)
+		for i, row := range page {
+			if i > 0 {
+				query.WriteString(", ")
+			}
+			query.WriteString("(" + placeholders(ph, len(args)+1, len(fields)) + ")")
+
+			rowval := reflect.ValueOf(row)
+			for _, f := range fields {
+				args = append(args, sqlFieldValue(rowval, f.index))
+			}
+		}
+
+		if _, err := db.ExecContext(ctx, query.String(), args...); err != nil {
+			return n, fmt.Errorf("inserting rows %d through %d: %w", n, n+len(page)-1, err)
+		}
+		n += len(page)
+	}
+
+	return n, nil
+}
+
 type sqlKindError struct {
 	kind reflect.Kind
 }
 
 func (e sqlKindError) Error() string {
-	return fmt.Sprintf("type parameter has %s kind but must be struct", e.kind)
+	return fmt.Sprintf("type parameter has %s kind but must be struct or scalar", e.kind)
+}
+
+type sqlInsertKindError struct {
+	typ reflect.Type
+}
+
+func (e sqlInsertKindError) Error() string {
+	return fmt.Sprintf("type parameter is %s but must be a non-scalar struct type", e.typ)
 }
 
 func sqlHelper[T any](ctx context.Context, rows *sql.Rows, yield func(T) bool) error {
-	var (
-		t  T
-		tt = reflect.TypeOf(t)
-	)
+	tt := reflect.TypeFor[T]()
 
-	if isSqlNull(tt) {
+	if isSqlScalar(tt) {
 		return sqlHelperScalar[T](ctx, rows, yield)
 	}
-
-	switch tt.Kind() {
-	case reflect.Struct:
+	if tt.Kind() == reflect.Struct {
 		return sqlHelperStruct[T](ctx, tt, rows, yield)
+	}
+	return sqlKindError{kind: tt.Kind()}
+}
+
+var (
+	scannerType = reflect.TypeFor[sql.Scanner]()
+	timeType    = reflect.TypeFor[time.Time]()
+)
 
+// isSqlScalar tells whether a value of type tt can be populated from a single column by [sql.Rows.Scan].
+func isSqlScalar(tt reflect.Type) bool {
+	if isSqlNull(tt) || tt == timeType || reflect.PointerTo(tt).Implements(scannerType) {
+		return true
+	}
+
+	switch tt.Kind() {
 	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64, reflect.String:
-		return sqlHelperScalar[T](ctx, rows, yield)
+		return true
 
-	default:
-		return sqlKindError{kind: tt.Kind()}
+	case reflect.Pointer:
+		return true
+
+	case reflect.Slice:
+		return tt.Elem().Kind() == reflect.Uint8
 	}
+
+	return false
 }
 
 func isSqlNull(tt reflect.Type) bool {
@@ -105,9 +612,17 @@ func isSqlNull(tt reflect.Type) bool {
 }
 
 func sqlHelperStruct[T any](ctx context.Context, rowtype reflect.Type, rows *sql.Rows, yield func(T) bool) error {
-	nfields := rowtype.NumField()
+	cols, err := rows.Columns()
+	if err != nil {
+		return fmt.Errorf("getting columns: %w", err)
+	}
 
-	for rows.Next() {
+	indexes, err := sqlPlanFor(rowtype).columnIndexes(rowtype, cols)
+	if err != nil {
+		return err
+	}
+
+	return sqlScanRows(ctx, rows, yield, func() (T, error) {
 		var (
 			// Note: We cannot use:
 			//   var row T
@@ -117,33 +632,234 @@ func sqlHelperStruct[T any](ctx context.Context, rowtype reflect.Type, rows *sql
 
 			rowptrval = reflect.New(rowtype)
 			rowval    = rowptrval.Elem()
-			ptrs      = make([]any, 0, nfields)
+			ptrs      = make([]any, 0, len(indexes))
 		)
 
-		for i := 0; i < nfields; i++ {
-			addr := rowval.Field(i).Addr()
-			ptrs = append(ptrs, addr.Interface())
+		for _, index := range indexes {
+			ptrs = append(ptrs, sqlFieldAddr(rowval, index))
 		}
-		if err := rows.Scan(ptrs...); err != nil {
-			return fmt.Errorf("scanning row: %w", err)
+		err := rows.Scan(ptrs...)
+		return rowval.Interface().(T), err
+	})
+}
+
+// sqlPlan describes how query-result columns map onto the fields of a struct type.
+type sqlPlan struct {
+	fields []sqlField
+	byName map[string]int // column name -> index in fields
+	byFold map[string]int // lowercased column name -> index in fields
+}
+
+type sqlField struct {
+	name  string // the column name, from the db tag or else the field name
+	index []int  // for reflect.Value.FieldByIndex
+}
+
+// sqlPlans caches a *sqlPlan for each struct type.
+var sqlPlans sync.Map
+
+func sqlPlanFor(tt reflect.Type) *sqlPlan {
+	if p, ok := sqlPlans.Load(tt); ok {
+		return p.(*sqlPlan)
+	}
+
+	p := &sqlPlan{
+		byName: make(map[string]int),
+		byFold: make(map[string]int),
+	}
+
+	// As with Go's own promoted fields,
+	// a field at a shallower depth of embedding takes precedence over a deeper one with the same name.
+	fields := sqlStructFields(tt, nil, map[reflect.Type]bool{tt: true})
+	slices.SortStableFunc(fields, func(a, b sqlField) int {
+		return len(a.index) - len(b.index)
+	})
+	for _, f := range fields {
+		p.add(f)
+	}
+
+	actual, _ := sqlPlans.LoadOrStore(tt, p)
+	return actual.(*sqlPlan)
+}
+
+// sqlStructFields produces the column-mapped fields of the struct type tt,
+// flattening embedded structs.
+// The visiting set holds the struct types being flattened,
+// including tt.
+// As in encoding/json,
+// an embedded struct whose type is already being visited is skipped,
+// so that a type like struct{ *Node } does not recurse forever.
+func sqlStructFields(tt reflect.Type, prefix []int, visiting map[reflect.Type]bool) []sqlField {
+	var result []sqlField
+
+	for i := 0; i < tt.NumField(); i++ {
+		sf := tt.Field(i)
+
+		name := sf.Name
+		if tag, ok := sf.Tag.Lookup("db"); ok {
+			tag, _, _ = strings.Cut(tag, ",")
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				name = tag
+			}
 		}
 
-		if err := ctx.Err(); err != nil {
-			return err
+		index := append(slices.Clip(prefix), i)
+
+		if sf.Anonymous && name == sf.Name {
+			ft := sf.Type
+			isPtr := ft.Kind() == reflect.Pointer
+			if isPtr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct && !isSqlScalar(ft) {
+				// Pointers to unexported embedded structs cannot be allocated via reflection.
+				if (!isPtr || sf.IsExported()) && !visiting[ft] {
+					visiting[ft] = true
+					result = append(result, sqlStructFields(ft, index, visiting)...)
+					delete(visiting, ft)
+				}
+				continue
+			}
 		}
 
-		if !yield(rowval.Interface().(T)) {
-			return nil
+		if !sf.IsExported() {
+			continue
 		}
+
+		result = append(result, sqlField{name: name, index: index})
 	}
 
-	return rows.Err()
+	return result
+}
+
+// sqlFieldAddr returns a pointer to the field of v at the given index,
+// allocating any nil embedded struct pointers along the way.
+func sqlFieldAddr(v reflect.Value, index []int) any {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v.Addr().Interface()
+}
+
+// sqlFieldValue returns the value of the field of v at the given index,
+// or nil if that field is inside a nil embedded struct pointer.
+func sqlFieldValue(v reflect.Value, index []int) any {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return nil
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v.Interface()
+}
+
+// columns produces the fields of the plan,
+// omitting any that are shadowed by another field with the same name.
+func (p *sqlPlan) columns() []sqlField {
+	var result []sqlField
+	for i, f := range p.fields {
+		if p.byName[f.name] == i {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+func (p *sqlPlan) add(f sqlField) {
+	n := len(p.fields)
+	p.fields = append(p.fields, f)
+	if _, ok := p.byName[f.name]; !ok {
+		p.byName[f.name] = n
+	}
+	folded := strings.ToLower(f.name)
+	if _, ok := p.byFold[folded]; !ok {
+		p.byFold[folded] = n
+	}
+}
+
+// columnIndexes produces the field index (for reflect.Value.FieldByIndex)
+// for each of the given columns.
+func (p *sqlPlan) columnIndexes(tt reflect.Type, cols []string) ([][]int, error) {
+	var (
+		result    = make([][]int, 0, len(cols))
+		matched   = make(map[int]string) // index in p.fields -> first column matching it
+		unmatched []string
+		dupErr    error
+	)
+
+	for _, col := range cols {
+		i, ok := p.byName[col]
+		if !ok {
+			i, ok = p.byFold[strings.ToLower(col)]
+		}
+		if !ok {
+			unmatched = append(unmatched, col)
+			continue
+		}
+		if prev, ok := matched[i]; ok {
+			if dupErr == nil {
+				dupErr = sqlDuplicateColumnError{typ: tt, field: tt.FieldByIndex(p.fields[i].index).Name, cols: []string{prev, col}}
+			}
+			continue
+		}
+		matched[i] = col
+		result = append(result, p.fields[i].index)
+	}
+
+	if len(unmatched) > 0 {
+		return nil, sqlColumnsError{typ: tt, cols: unmatched}
+	}
+	if dupErr != nil {
+		return nil, dupErr
+	}
+	return result, nil
+}
+
+type sqlColumnsError struct {
+	typ  reflect.Type
+	cols []string
+}
+
+func (e sqlColumnsError) Error() string {
+	return fmt.Sprintf("no field in %s for column(s) %s", e.typ, strings.Join(e.cols, ", "))
+}
+
+type sqlDuplicateColumnError struct {
+	typ   reflect.Type
+	field string
+	cols  []string
+}
+
+func (e sqlDuplicateColumnError) Error() string {
+	return fmt.Sprintf("column(s) %s all match field %s in %s", strings.Join(e.cols, ", "), e.field, e.typ)
 }
 
 func sqlHelperScalar[T any](ctx context.Context, rows *sql.Rows, yield func(T) bool) error {
-	for rows.Next() {
+	return sqlScanRows(ctx, rows, yield, func() (T, error) {
 		var val T
-		if err := rows.Scan(&val); err != nil {
+		err := rows.Scan(&val)
+		return val, err
+	})
+}
+
+// sqlScanRows calls scan on each row of rows and yields the result.
+// It stops early if the context is canceled.
+func sqlScanRows[T any](ctx context.Context, rows *sql.Rows, yield func(T) bool, scan func() (T, error)) error {
+	for rows.Next() {
+		val, err := scan()
+		if err != nil {
 			return fmt.Errorf("scanning row: %w", err)
 		}
 
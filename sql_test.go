@@ -1,13 +1,18 @@
 package seqs
 
 import (
+	"cmp"
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
 	"slices"
 	"testing"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 )
@@ -141,14 +146,553 @@ func TestSQL(t *testing.T) {
 		}
 	})
 
+	t.Run("Tags", func(t *testing.T) {
+		type taggedEmployee struct {
+			Pay      int    `db:"salary"`
+			FullName string `db:"name"`
+			Comment  string `db:"-"`
+		}
+
+		it, errptr := SQL[taggedEmployee](ctx, db, `SELECT * FROM employees ORDER BY name`)
+		got := slices.Collect(it)
+		if *errptr != nil {
+			t.Fatal(*errptr)
+		}
+
+		var wantTagged []taggedEmployee
+		for _, emp := range want {
+			wantTagged = append(wantTagged, taggedEmployee{Pay: emp.Salary, FullName: emp.Name})
+		}
+		if !slices.Equal(got, wantTagged) {
+			t.Errorf("got %v, want %v", got, wantTagged)
+		}
+	})
+
+	t.Run("UnnamedColumn", func(t *testing.T) {
+		type pair struct {
+			A string
+			B int
+		}
+
+		it, errptr := SQL[pair](ctx, db, `SELECT name, salary + 1 FROM employees ORDER BY name`)
+		if got := slices.Collect(it); len(got) != 0 {
+			t.Errorf("got %d rows, want 0", len(got))
+		}
+
+		var e sqlColumnsError
+		if !errors.As(*errptr, &e) {
+			t.Fatalf("got %v, want sqlColumnsError", *errptr)
+		}
+		if want := []string{"name", "salary + 1"}; !slices.Equal(e.cols, want) {
+			t.Errorf("got unmatched columns %v, want %v", e.cols, want)
+		}
+	})
+
+	t.Run("DuplicateColumn", func(t *testing.T) {
+		it, errptr := SQL[employee](ctx, db, `SELECT name, salary, name AS NAME FROM employees`)
+		if got := slices.Collect(it); len(got) != 0 {
+			t.Errorf("got %d rows, want 0", len(got))
+		}
+
+		var e sqlDuplicateColumnError
+		if !errors.As(*errptr, &e) {
+			t.Fatalf("got %v, want sqlDuplicateColumnError", *errptr)
+		}
+		if e.field != "Name" || !slices.Equal(e.cols, []string{"name", "NAME"}) {
+			t.Errorf("got field %s and columns %v, want Name and [name NAME]", e.field, e.cols)
+		}
+	})
+
+	t.Run("ColumnsError", func(t *testing.T) {
+		type nameOnly struct {
+			Name string
+			Age  int
+		}
+
+		it, errptr := SQL[nameOnly](ctx, db, q)
+		_ = slices.Collect(it)
+
+		var e sqlColumnsError
+		if !errors.As(*errptr, &e) {
+			t.Fatalf("got %v, want sqlColumnsError", *errptr)
+		}
+		if !slices.Equal(e.cols, []string{"salary"}) {
+			t.Errorf("got unmatched columns %v, want [salary]", e.cols)
+		}
+	})
+
 	t.Run("KindError", func(t *testing.T) {
-		it, errptr := SQL[*int](ctx, db, q)
+		it, errptr := SQL[map[string]int](ctx, db, q)
 		_ = slices.Collect(it)
 
 		var e sqlKindError
 		if !errors.As(*errptr, &e) {
-			e.kind = reflect.TypeOf(0).Kind()
+			e.kind = reflect.Map
 			t.Errorf("got %v, want %v", err, e)
 		}
 	})
 }
+
+func newTestDB(t *testing.T, schema string) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if _, err := db.Exec(schema); err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+type testLevel int
+
+const (
+	levelLow testLevel = iota + 1
+	levelHigh
+)
+
+func (l *testLevel) Scan(src any) error {
+	var s string
+	switch src := src.(type) {
+	case string:
+		s = src
+	case []byte:
+		s = string(src)
+	default:
+		return fmt.Errorf("cannot scan %T into testLevel", src)
+	}
+	switch s {
+	case "low":
+		*l = levelLow
+	case "high":
+		*l = levelHigh
+	default:
+		return fmt.Errorf("unknown level %q", s)
+	}
+	return nil
+}
+
+func TestSQLTypes(t *testing.T) {
+	const typesSchema = `
+CREATE TABLE events (
+  id INT NOT NULL,
+  at TIMESTAMP NOT NULL,
+  level TEXT NOT NULL,
+  note TEXT,
+  payload BLOB NOT NULL
+);
+`
+	var (
+		ctx = context.Background()
+		db  = newTestDB(t, typesSchema)
+		t1  = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		t2  = time.Date(2024, 6, 7, 8, 9, 10, 0, time.UTC)
+	)
+
+	_, err := db.ExecContext(ctx, `INSERT INTO events (id, at, level, note, payload) VALUES (1, $1, 'low', NULL, $2), (2, $3, 'high', 'hi', $4)`, t1, []byte("one"), t2, []byte("two"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Time", func(t *testing.T) {
+		it, errptr := SQL[time.Time](ctx, db, `SELECT at FROM events ORDER BY id`)
+		got := slices.Collect(it)
+		if *errptr != nil {
+			t.Fatal(*errptr)
+		}
+		want := []time.Time{t1, t2}
+		if !slices.EqualFunc(got, want, time.Time.Equal) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Bytes", func(t *testing.T) {
+		it, errptr := SQL[json.RawMessage](ctx, db, `SELECT payload FROM events ORDER BY id`)
+		got := slices.Collect(it)
+		if *errptr != nil {
+			t.Fatal(*errptr)
+		}
+		want := []json.RawMessage{json.RawMessage("one"), json.RawMessage("two")}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("Pointer", func(t *testing.T) {
+		it, errptr := SQL[*string](ctx, db, `SELECT note FROM events ORDER BY id`)
+		got := slices.Collect(it)
+		if *errptr != nil {
+			t.Fatal(*errptr)
+		}
+		if len(got) != 2 {
+			t.Fatalf("got %d values, want 2", len(got))
+		}
+		if got[0] != nil {
+			t.Errorf("got %q, want nil", *got[0])
+		}
+		if got[1] == nil || *got[1] != "hi" {
+			t.Errorf("got %v, want pointer to \"hi\"", got[1])
+		}
+	})
+
+	t.Run("Scanner", func(t *testing.T) {
+		it, errptr := SQL[testLevel](ctx, db, `SELECT level FROM events ORDER BY id`)
+		got := slices.Collect(it)
+		if *errptr != nil {
+			t.Fatal(*errptr)
+		}
+		want := []testLevel{levelLow, levelHigh}
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Embedded", func(t *testing.T) {
+		type meta struct {
+			ID int
+			At time.Time
+		}
+		type Detail struct {
+			Note *string
+		}
+		type event struct {
+			meta
+			*Detail
+			Level   testLevel
+			Payload []byte
+		}
+
+		it, errptr := SQL[event](ctx, db, `SELECT * FROM events ORDER BY id`)
+		got := slices.Collect(it)
+		if *errptr != nil {
+			t.Fatal(*errptr)
+		}
+		if len(got) != 2 {
+			t.Fatalf("got %d values, want 2", len(got))
+		}
+
+		e := got[1]
+		if e.ID != 2 || !e.At.Equal(t2) || e.Level != levelHigh || string(e.Payload) != "two" {
+			t.Errorf("got %+v", e)
+		}
+		if e.Detail == nil || e.Note == nil || *e.Note != "hi" {
+			t.Errorf("got detail %+v, want note \"hi\"", e.Detail)
+		}
+	})
+
+	t.Run("EmbeddedCycle", func(t *testing.T) {
+		type Node struct {
+			*Node
+			ID int
+		}
+
+		it, errptr := SQL[Node](ctx, db, `SELECT id FROM events ORDER BY id`)
+		got := slices.Collect(it)
+		if *errptr != nil {
+			t.Fatal(*errptr)
+		}
+		if len(got) != 2 || got[0].ID != 1 || got[1].ID != 2 || got[0].Node != nil {
+			t.Errorf("got %+v", got)
+		}
+
+		it2, errptr := SQL[CycleA](ctx, db, `SELECT id, note FROM events ORDER BY id`)
+		got2 := slices.Collect(it2)
+		if *errptr != nil {
+			t.Fatal(*errptr)
+		}
+		if len(got2) != 2 || got2[1].ID != 2 || got2[1].CycleB == nil || got2[1].Note == nil || *got2[1].Note != "hi" {
+			t.Errorf("got %+v", got2)
+		}
+	})
+}
+
+// CycleA and CycleB embed each other, for testing SQL with recursive embedded structs.
+// They are exported so that their embedded fields are too.
+type (
+	CycleA struct {
+		*CycleB
+		ID int
+	}
+	CycleB struct {
+		*CycleA
+		Note *string
+	}
+)
+
+func TestInsertSQL(t *testing.T) {
+	var (
+		ctx = context.Background()
+		db  = newTestDB(t, schema)
+	)
+
+	type employee struct {
+		Name   string `db:"name"`
+		Salary int    `db:"salary"`
+		Notes  string `db:"-"`
+	}
+
+	var want []employee
+	for i := 0; i < 25; i++ {
+		want = append(want, employee{Name: fmt.Sprintf("emp%02d", i), Salary: 1000 * i})
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, err := InsertSQL(ctx, tx, "employees", slices.Values(want), &InsertSQLOptions{BatchSize: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if n != len(want) {
+		t.Errorf("inserted %d rows, want %d", n, len(want))
+	}
+
+	it, errptr := SQL[employee](ctx, db, `SELECT name, salary FROM employees ORDER BY name`)
+	got := slices.Collect(it)
+	if *errptr != nil {
+		t.Fatal(*errptr)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	t.Run("Error", func(t *testing.T) {
+		type bogus struct {
+			Name  string
+			Bogus int
+		}
+		n, err := InsertSQL(ctx, db, "employees", slices.Values([]bogus{{Name: "x"}}), nil)
+		if err == nil {
+			t.Fatal("got no error, want one")
+		}
+		if n != 0 {
+			t.Errorf("inserted %d rows, want 0", n)
+		}
+	})
+
+	t.Run("KindError", func(t *testing.T) {
+		var e sqlInsertKindError
+
+		_, err := InsertSQL(ctx, db, "employees", slices.Values([]int{1}), nil)
+		if !errors.As(err, &e) {
+			t.Errorf("got %v for int, want a sqlInsertKindError", err)
+		}
+
+		// A struct type, but one that SQL treats as a scalar.
+		_, err = InsertSQL(ctx, db, "employees", slices.Values([]time.Time{{}}), nil)
+		if !errors.As(err, &e) {
+			t.Errorf("got %v for time.Time, want a sqlInsertKindError", err)
+		}
+	})
+
+	t.Run("Atomic", func(t *testing.T) {
+		type nullableEmployee struct {
+			Name   string `db:"name"`
+			Salary *int   `db:"salary"`
+		}
+
+		salary := 1
+		rows := []nullableEmployee{
+			{Name: "atomic0", Salary: &salary},
+			{Name: "atomic1", Salary: &salary},
+			{Name: "atomic2"}, // violates NOT NULL, in the second batch
+		}
+
+		for _, atomic := range []bool{true, false} {
+			n, err := InsertSQL(ctx, db, "employees", slices.Values(rows), &InsertSQLOptions{BatchSize: 2, Atomic: atomic})
+			if err == nil {
+				t.Fatalf("atomic=%t: got no error, want one", atomic)
+			}
+
+			wantN := 0
+			if !atomic {
+				wantN = 2
+			}
+			if n != wantN {
+				t.Errorf("atomic=%t: inserted %d rows, want %d", atomic, n, wantN)
+			}
+
+			var count int
+			if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM employees WHERE name LIKE 'atomic%'`).Scan(&count); err != nil {
+				t.Fatal(err)
+			}
+			if count != wantN {
+				t.Errorf("atomic=%t: found %d rows, want %d", atomic, count, wantN)
+			}
+		}
+	})
+
+	t.Run("AtomicTx", func(t *testing.T) {
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer tx.Rollback()
+
+		_, err = InsertSQL(ctx, tx, "employees", slices.Values(want), &InsertSQLOptions{Atomic: true})
+		if err == nil {
+			t.Error("got no error for atomic insert with a *sql.Tx, want one")
+		}
+	})
+
+	t.Run("Dollar", func(t *testing.T) {
+		db := newTestDB(t, schema)
+
+		n, err := InsertSQL(ctx, db, "employees", slices.Values(want), &InsertSQLOptions{BatchSize: 7, Placeholder: DollarPlaceholder})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != len(want) {
+			t.Errorf("inserted %d rows, want %d", n, len(want))
+		}
+
+		it, errptr := SQLKeyset[employee](ctx, db, `SELECT name, salary FROM employees WHERE salary >= $1`, []string{"name"}, 4, DollarPlaceholder, 5000)
+		got := slices.Collect(it)
+		if *errptr != nil {
+			t.Fatal(*errptr)
+		}
+		if !slices.Equal(got, want[5:]) {
+			t.Errorf("got %v, want %v", got, want[5:])
+		}
+	})
+}
+
+func TestPlaceholders(t *testing.T) {
+	if got, want := placeholders(QuestionPlaceholder, 3, 3), "?, ?, ?"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := placeholders(DollarPlaceholder, 3, 3), "$3, $4, $5"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+type countingQueryer struct {
+	QueryerContext
+	n int
+}
+
+func (c *countingQueryer) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	c.n++
+	return c.QueryerContext.QueryContext(ctx, query, args...)
+}
+
+func TestSQLKeyset(t *testing.T) {
+	var (
+		ctx = context.Background()
+		db  = newTestDB(t, schema)
+	)
+
+	type employee struct {
+		Name   string
+		Salary int
+	}
+
+	var inp []employee
+	for i := 0; i < 25; i++ {
+		inp = append(inp, employee{Name: fmt.Sprintf("emp%02d", i), Salary: 1000 * (i % 4)})
+	}
+	if _, err := InsertSQL(ctx, db, "employees", slices.Values(inp), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Single", func(t *testing.T) {
+		cq := &countingQueryer{QueryerContext: db}
+		it, errptr := SQLKeyset[employee](ctx, cq, `SELECT name, salary FROM employees WHERE salary > ?`, []string{"name"}, 7, nil, 0)
+		got := slices.Collect(it)
+		if *errptr != nil {
+			t.Fatal(*errptr)
+		}
+
+		want := slices.Collect(Filter(slices.Values(inp), func(e employee) bool { return e.Salary > 0 }))
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+		if cq.n != 3 {
+			t.Errorf("got %d queries, want 3", cq.n)
+		}
+	})
+
+	t.Run("Composite", func(t *testing.T) {
+		it, errptr := SQLKeyset[employee](ctx, db, `SELECT name, salary FROM employees`, []string{"salary", "name"}, 4, nil)
+		got := slices.Collect(it)
+		if *errptr != nil {
+			t.Fatal(*errptr)
+		}
+
+		want := slices.Clone(inp)
+		slices.SortFunc(want, func(a, b employee) int {
+			if c := cmp.Compare(a.Salary, b.Salary); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.Name, b.Name)
+		})
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Scalar", func(t *testing.T) {
+		cq := &countingQueryer{QueryerContext: db}
+		it, errptr := SQLKeyset[string](ctx, cq, `SELECT name FROM employees`, []string{"name"}, 5, nil)
+		got := slices.Collect(Limit(it, 7))
+		if *errptr != nil {
+			t.Fatal(*errptr)
+		}
+
+		want := []string{"emp00", "emp01", "emp02", "emp03", "emp04", "emp05", "emp06"}
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+		if cq.n != 2 {
+			t.Errorf("got %d queries, want 2", cq.n)
+		}
+	})
+
+	t.Run("BadKey", func(t *testing.T) {
+		_, errptr := SQLKeyset[employee](ctx, db, `SELECT name, salary FROM employees`, []string{"id"}, 5, nil)
+		if *errptr == nil {
+			t.Error("got no error, want one")
+		}
+	})
+}
+
+func TestSQLRecords(t *testing.T) {
+	var (
+		ctx = context.Background()
+		db  = newTestDB(t, `CREATE TABLE things (id INT NOT NULL, name TEXT, price REAL);`)
+	)
+
+	_, err := db.ExecContext(ctx, `INSERT INTO things (id, name, price) VALUES (1, 'apple', 1.5), (2, NULL, 2.25)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	it, errptr := SQLRecords(ctx, db, `SELECT id, name, price, id * 10 AS tens FROM things ORDER BY id`)
+	var got []map[string]any
+	for rec := range it {
+		if !slices.Equal(rec.Columns, []string{"id", "name", "price", "tens"}) {
+			t.Errorf("got columns %v", rec.Columns)
+		}
+		got = append(got, rec.Map())
+	}
+	if *errptr != nil {
+		t.Fatal(*errptr)
+	}
+
+	want := []map[string]any{
+		{"id": int64(1), "name": "apple", "price": 1.5, "tens": int64(10)},
+		{"id": int64(2), "name": nil, "price": 2.25, "tens": int64(20)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
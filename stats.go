@@ -0,0 +1,134 @@
+package seqs
+
+import (
+	"iter"
+	"math"
+)
+
+// Number is a constraint that permits any integer or floating-point type.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Stats holds summary statistics for a sequence of numbers.
+// The zero Stats is ready to use and describes an empty sequence.
+//
+// Stats values from separate parts of a sequence,
+// for instance ones computed in parallel,
+// may be combined with [Stats.Merge].
+type Stats[T Number] struct {
+	Count int
+	Sum   T // note: for integer types, this may overflow
+
+	Min, Max       T   // zero if Count is 0
+	MinPos, MaxPos int // the positions in the sequence of the first occurrences of Min and Max
+
+	mean, m2 float64 // running mean and sum of squared differences from the mean, per Welford
+}
+
+// Summarize computes summary statistics for the numbers in inp.
+// It consumes the entire input sequence.
+// Beware of infinite input!
+func Summarize[T Number](inp iter.Seq[T]) Stats[T] {
+	var s Stats[T]
+	for x := range inp {
+		s.Add(x)
+	}
+	return s
+}
+
+// RunningStats produces an iterator over the summary statistics of inp,
+// updated after each element.
+// It is equivalent to calling [Accum] with [Stats.Add].
+func RunningStats[T Number](inp iter.Seq[T]) iter.Seq[Stats[T]] {
+	return Accum(inp, Stats[T]{}, func(s Stats[T], x T) Stats[T] {
+		s.Add(x)
+		return s
+	})
+}
+
+// Add updates s with the next number in the sequence.
+func (s *Stats[T]) Add(x T) {
+	if s.Count == 0 || x < s.Min {
+		s.Min, s.MinPos = x, s.Count
+	}
+	if s.Count == 0 || x > s.Max {
+		s.Max, s.MaxPos = x, s.Count
+	}
+
+	s.Count++
+	s.Sum += x
+
+	delta := float64(x) - s.mean
+	s.mean += delta / float64(s.Count)
+	s.m2 += delta * (float64(x) - s.mean)
+}
+
+// Merge returns the statistics of the concatenation of the sequences described by s and other,
+// with s's sequence first.
+// The positions in other are offset by s.Count accordingly.
+func (s Stats[T]) Merge(other Stats[T]) Stats[T] {
+	if other.Count == 0 {
+		return s
+	}
+	if s.Count == 0 {
+		return other
+	}
+
+	result := s
+	if other.Min < s.Min {
+		result.Min, result.MinPos = other.Min, s.Count+other.MinPos
+	}
+	if other.Max > s.Max {
+		result.Max, result.MaxPos = other.Max, s.Count+other.MaxPos
+	}
+
+	var (
+		n1, n2 = float64(s.Count), float64(other.Count)
+		n      = n1 + n2
+		delta  = other.mean - s.mean
+	)
+
+	result.Count = s.Count + other.Count
+	result.Sum = s.Sum + other.Sum
+	result.mean = s.mean + delta*n2/n
+	result.m2 = s.m2 + other.m2 + delta*delta*n1*n2/n
+
+	return result
+}
+
+// Mean returns the arithmetic mean of the sequence,
+// or NaN if it is empty.
+func (s Stats[T]) Mean() float64 {
+	if s.Count == 0 {
+		return math.NaN()
+	}
+	return s.mean
+}
+
+// Variance returns the population variance of the sequence,
+// or NaN if it is empty.
+func (s Stats[T]) Variance() float64 {
+	if s.Count == 0 {
+		return math.NaN()
+	}
+	return s.m2 / float64(s.Count)
+}
+
+// SampleVariance returns the sample variance of the sequence
+// (with Bessel's correction),
+// or NaN if it has fewer than two elements.
+func (s Stats[T]) SampleVariance() float64 {
+	if s.Count < 2 {
+		return math.NaN()
+	}
+	return s.m2 / float64(s.Count-1)
+}
+
+// StdDev returns the population standard deviation of the sequence,
+// or NaN if it is empty.
+func (s Stats[T]) StdDev() float64 {
+	return math.Sqrt(s.Variance())
+}
@@ -0,0 +1,109 @@
+package seqs
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestSummarize(t *testing.T) {
+	var (
+		vals = []int{4, 7, 2, 9, 2, 9, 5}
+		s    = Summarize(slices.Values(vals))
+	)
+	if s.Count != 7 {
+		t.Errorf("got count %d, want 7", s.Count)
+	}
+	if s.Sum != 38 {
+		t.Errorf("got sum %d, want 38", s.Sum)
+	}
+	if s.Min != 2 || s.MinPos != 2 {
+		t.Errorf("got min %d at %d, want 2 at 2", s.Min, s.MinPos)
+	}
+	if s.Max != 9 || s.MaxPos != 3 {
+		t.Errorf("got max %d at %d, want 9 at 3", s.Max, s.MaxPos)
+	}
+
+	var (
+		wantMean = 38.0 / 7
+		wantVar  float64
+	)
+	for _, v := range vals {
+		d := float64(v) - wantMean
+		wantVar += d * d
+	}
+	wantVar /= 7
+
+	if !approxEqual(s.Mean(), wantMean) {
+		t.Errorf("got mean %v, want %v", s.Mean(), wantMean)
+	}
+	if !approxEqual(s.Variance(), wantVar) {
+		t.Errorf("got variance %v, want %v", s.Variance(), wantVar)
+	}
+	if want := wantVar * 7 / 6; !approxEqual(s.SampleVariance(), want) {
+		t.Errorf("got sample variance %v, want %v", s.SampleVariance(), want)
+	}
+	if want := math.Sqrt(wantVar); !approxEqual(s.StdDev(), want) {
+		t.Errorf("got stddev %v, want %v", s.StdDev(), want)
+	}
+}
+
+func TestSummarizeEmpty(t *testing.T) {
+	s := Summarize(Empty[float64])
+	if s.Count != 0 {
+		t.Errorf("got count %d, want 0", s.Count)
+	}
+	if !math.IsNaN(s.Mean()) {
+		t.Errorf("got mean %v, want NaN", s.Mean())
+	}
+	if !math.IsNaN(s.Variance()) {
+		t.Errorf("got variance %v, want NaN", s.Variance())
+	}
+}
+
+func TestStatsMerge(t *testing.T) {
+	var (
+		vals = []float64{3.5, -1, 8, 2.25, 8, -1, 0, 6}
+		want = Summarize(slices.Values(vals))
+	)
+	for split := 0; split <= len(vals); split++ {
+		var (
+			a   = Summarize(slices.Values(vals[:split]))
+			b   = Summarize(slices.Values(vals[split:]))
+			got = a.Merge(b)
+		)
+		if got.Count != want.Count || got.Sum != want.Sum {
+			t.Errorf("split %d: got count %d sum %v, want %d and %v", split, got.Count, got.Sum, want.Count, want.Sum)
+		}
+		if got.Min != want.Min || got.MinPos != want.MinPos {
+			t.Errorf("split %d: got min %v at %d, want %v at %d", split, got.Min, got.MinPos, want.Min, want.MinPos)
+		}
+		if got.Max != want.Max || got.MaxPos != want.MaxPos {
+			t.Errorf("split %d: got max %v at %d, want %v at %d", split, got.Max, got.MaxPos, want.Max, want.MaxPos)
+		}
+		if !approxEqual(got.Mean(), want.Mean()) {
+			t.Errorf("split %d: got mean %v, want %v", split, got.Mean(), want.Mean())
+		}
+		if !approxEqual(got.Variance(), want.Variance()) {
+			t.Errorf("split %d: got variance %v, want %v", split, got.Variance(), want.Variance())
+		}
+	}
+}
+
+func TestRunningStats(t *testing.T) {
+	var (
+		running = RunningStats(slices.Values([]uint8{5, 1, 3}))
+		got     []float64
+	)
+	for s := range running {
+		got = append(got, s.Mean())
+	}
+	want := []float64{5, 3, 3}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-9*max(1, math.Abs(a), math.Abs(b))
+}
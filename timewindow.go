@@ -0,0 +1,232 @@
+package seqs
+
+import (
+	"context"
+	"iter"
+	"slices"
+	"time"
+)
+
+// Clock is a source of time for [TimeWindows] and [EventTimeWindows].
+// Substituting a fake implementation makes those functions deterministic in tests.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// After returns a channel that receives the current time once d has elapsed,
+	// as with [time.After].
+	After(d time.Duration) <-chan time.Time
+}
+
+// SystemClock is a [Clock] that uses the real time of the system.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// TimeWindow is a group of elements from a sequence
+// whose times fall in the half-open interval [Start, End).
+type TimeWindow[T any] struct {
+	Start, End time.Time
+	Items      []T
+}
+
+// TimeWindows groups the elements of inp into windows by the time at which they arrive,
+// according to clock.
+// If clock is nil, [SystemClock] is used.
+//
+// Windows are size long, and a new window begins every slide.
+// If slide equals size, the windows are adjacent ("tumbling" windows).
+// If slide is less than size, the windows overlap ("sliding" windows)
+// and an element may appear in more than one of them.
+// Window boundaries are multiples of slide since the zero [time.Time],
+// as with [time.Time.Truncate],
+// so that for instance 5-second windows begin on 5-second boundaries of the clock.
+//
+// An element's arrival time is read from clock when the element is received,
+// so it is never earlier than the end of a window already produced,
+// and no element is dropped.
+//
+// Each window is produced as soon as its end time passes,
+// even if no new element has arrived,
+// and windows are produced in order of their start times.
+// Windows with no elements are skipped.
+// When the input ends, any remaining windows are produced immediately.
+//
+// The input is consumed in a separate goroutine (see [ToChanContext]).
+// Iteration stops early if ctx is canceled,
+// in which case the caller can dereference the returned error pointer to get the error
+// (such as [context.Canceled] or [context.DeadlineExceeded]),
+// but only after iteration is done.
+//
+// If size or slide is not positive, the output is empty.
+func TimeWindows[T any](ctx context.Context, inp iter.Seq[T], size, slide time.Duration, clock Clock) (iter.Seq[TimeWindow[T]], *error) {
+	return timeWindows(ctx, inp, size, slide, nil, 0, clock)
+}
+
+// EventTimeWindows groups the elements of inp into windows by timestamps that the function ts extracts from them.
+// It is otherwise like [TimeWindows],
+// except in how it decides when a window is complete.
+//
+// Elements may arrive somewhat out of timestamp order.
+// EventTimeWindows tracks a "watermark,"
+// which is the greatest timestamp seen so far, less the allowed lateness.
+// A window is produced once the watermark reaches its end time.
+// An element arriving after all of its windows have been produced is dropped.
+//
+// While no elements are arriving,
+// the watermark advances with clock,
+// so that windows are still produced on an idle stream.
+// If clock is nil, [SystemClock] is used.
+func EventTimeWindows[T any](ctx context.Context, inp iter.Seq[T], size, slide time.Duration, ts func(T) time.Time, lateness time.Duration, clock Clock) (iter.Seq[TimeWindow[T]], *error) {
+	return timeWindows(ctx, inp, size, slide, ts, lateness, clock)
+}
+
+// timeWindows implements TimeWindows (when ts is nil) and EventTimeWindows.
+func timeWindows[T any](ctx context.Context, inp iter.Seq[T], size, slide time.Duration, ts func(T) time.Time, lateness time.Duration, clock Clock) (iter.Seq[TimeWindow[T]], *error) {
+	if clock == nil {
+		clock = SystemClock
+	}
+
+	var err error
+
+	f := func(yield func(TimeWindow[T]) bool) {
+		if size <= 0 || slide <= 0 {
+			return
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		ch, _ := ToChanContext(ctx, inp)
+
+		var (
+			open = make(map[time.Time]*TimeWindow[T]) // keyed by start time
+			done time.Time                            // windows ending at or before this have been produced
+
+			// For event time: the greatest timestamp seen, and the arrival time of its element.
+			maxTS, maxAt time.Time
+			seen         bool
+
+			timer    <-chan time.Time
+			timerEnd time.Time // the window end time for which timer is set
+		)
+
+		watermark := func(now time.Time) time.Time {
+			if ts == nil {
+				return now
+			}
+			if !seen {
+				return time.Time{}
+			}
+			return maxTS.Add(now.Sub(maxAt)).Add(-lateness)
+		}
+
+		// Add val, which arrived at time at, to its open windows.
+		// For processing time,
+		// at is read from the clock in this goroutine after the last call to emit,
+		// so it is never in a window that has already been produced.
+		add := func(val T, at time.Time) {
+			t := at
+			if ts != nil {
+				t = ts(val)
+				if !seen || t.After(maxTS) {
+					maxTS, maxAt, seen = t, at, true
+				}
+			}
+
+			for start := t.Truncate(slide); start.Add(size).After(t); start = start.Add(-slide) {
+				end := start.Add(size)
+				if !end.After(done) {
+					// This window and all earlier ones have already been produced.
+					break
+				}
+				w, ok := open[start]
+				if !ok {
+					w = &TimeWindow[T]{Start: start, End: end}
+					open[start] = w
+				}
+				w.Items = append(w.Items, val)
+			}
+		}
+
+		// Produce the windows ending at or before wm.
+		emit := func(wm time.Time) bool {
+			if wm.After(done) {
+				done = wm
+			}
+
+			var ready []*TimeWindow[T]
+			for start, w := range open {
+				if !w.End.After(wm) {
+					ready = append(ready, w)
+					delete(open, start)
+				}
+			}
+			slices.SortFunc(ready, func(a, b *TimeWindow[T]) int {
+				return a.Start.Compare(b.Start)
+			})
+			for _, w := range ready {
+				if !yield(*w) {
+					return false
+				}
+			}
+			return true
+		}
+
+		for {
+			var now time.Time
+
+			select {
+			case <-ctx.Done():
+				err = ctx.Err()
+				return
+
+			case val, ok := <-ch:
+				if !ok {
+					if err = ctx.Err(); err != nil {
+						return
+					}
+
+					// End of input. Produce everything that remains.
+					var last time.Time
+					for _, w := range open {
+						if w.End.After(last) {
+							last = w.End
+						}
+					}
+					emit(last)
+					return
+				}
+				now = clock.Now()
+				add(val, now)
+
+			case <-timer:
+				timer, timerEnd = nil, time.Time{}
+				now = clock.Now()
+			}
+
+			wm := watermark(now)
+			if !emit(wm) {
+				return
+			}
+
+			// Set a timer for when the earliest open window should close.
+			var first time.Time
+			for _, w := range open {
+				if first.IsZero() || w.End.Before(first) {
+					first = w.End
+				}
+			}
+			if first.IsZero() {
+				timer, timerEnd = nil, time.Time{}
+			} else if !first.Equal(timerEnd) {
+				timer, timerEnd = clock.After(first.Sub(wm)), first
+			}
+		}
+	}
+
+	return f, &err
+}
@@ -0,0 +1,302 @@
+package seqs
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a [Clock] whose time changes only when Advance is called.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	reads   int // the number of calls to Now
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reads++
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, fakeWaiter{at: c.now.Add(d), ch: ch})
+	return ch
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	c.waiters = slices.DeleteFunc(c.waiters, func(w fakeWaiter) bool {
+		if w.at.After(c.now) {
+			return false
+		}
+		w.ch <- c.now
+		return true
+	})
+}
+
+// Wait blocks until Now has been called at least reads times and a timer is pending.
+// Tests call this before Advance to be sure that the clock has been read
+// for each element received so far.
+func (c *fakeClock) Wait(reads int) {
+	for {
+		c.mu.Lock()
+		ok := c.reads >= reads && len(c.waiters) > 0
+		c.mu.Unlock()
+		if ok {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+var t0 = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestTimeWindows(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		clock = &fakeClock{now: t0}
+		idle  = make(chan struct{})
+		wake  = make(chan struct{})
+	)
+
+	inp := func(yield func(int) bool) {
+		if !yield(1) || !yield(2) {
+			return
+		}
+		close(idle)
+		<-wake
+		yield(3)
+	}
+
+	windows, errptr := TimeWindows(ctx, inp, 5*time.Second, 5*time.Second, clock)
+	out := ToChan(windows)
+
+	<-idle
+	clock.Wait(2)
+	clock.Advance(5 * time.Second)
+
+	// The first window is produced even though the input has not ended.
+	w, ok := <-out
+	if !ok {
+		t.Fatal("no first window")
+	}
+	want := TimeWindow[int]{Start: t0, End: t0.Add(5 * time.Second), Items: []int{1, 2}}
+	if !reflect.DeepEqual(w, want) {
+		t.Errorf("got %v, want %v", w, want)
+	}
+
+	close(wake)
+
+	w, ok = <-out
+	if !ok {
+		t.Fatal("no second window")
+	}
+	want = TimeWindow[int]{Start: t0.Add(5 * time.Second), End: t0.Add(10 * time.Second), Items: []int{3}}
+	if !reflect.DeepEqual(w, want) {
+		t.Errorf("got %v, want %v", w, want)
+	}
+
+	if _, ok := <-out; ok {
+		t.Error("got a third window")
+	}
+	if *errptr != nil {
+		t.Error(*errptr)
+	}
+}
+
+// scriptedClock is a [Clock] whose Now method returns the given times in order,
+// repeating the last one,
+// and whose timers fire immediately.
+type scriptedClock struct {
+	mu    sync.Mutex
+	times []time.Time
+}
+
+func (c *scriptedClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.times[0]
+	if len(c.times) > 1 {
+		c.times = c.times[1:]
+	}
+	return now
+}
+
+func (c *scriptedClock) After(time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	ch <- c.times[0]
+	return ch
+}
+
+func TestTimeWindowsLateDelivery(t *testing.T) {
+	// The clock passes the end of the first window,
+	// and its timer fires,
+	// between the arrival of the first element and the delivery of the second.
+	// The second element must go in the next window, not be dropped.
+	clock := &scriptedClock{times: []time.Time{t0.Add(4 * time.Second), t0.Add(5 * time.Second)}}
+
+	windows, errptr := TimeWindows(context.Background(), slices.Values([]int{1, 2}), 5*time.Second, 5*time.Second, clock)
+	got := slices.Collect(windows)
+	if *errptr != nil {
+		t.Fatal(*errptr)
+	}
+
+	want := []TimeWindow[int]{
+		{Start: t0, End: t0.Add(5 * time.Second), Items: []int{1}},
+		{Start: t0.Add(5 * time.Second), End: t0.Add(10 * time.Second), Items: []int{2}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+type testEvent struct {
+	sec  int
+	name string
+}
+
+func (e testEvent) time() time.Time {
+	return t0.Add(time.Duration(e.sec) * time.Second)
+}
+
+func eventNames(ws []TimeWindow[testEvent]) [][]string {
+	var result [][]string
+	for _, w := range ws {
+		var names []string
+		for _, e := range w.Items {
+			names = append(names, e.name)
+		}
+		result = append(result, names)
+	}
+	return result
+}
+
+func TestEventTimeWindows(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("tumbling", func(t *testing.T) {
+		var (
+			clock  = &fakeClock{now: t0}
+			events = slices.Values([]testEvent{
+				{1, "a"},
+				{5, "b"},
+				{11, "c"},
+				{9, "d"}, // out of order but within the allowed lateness
+				{13, "e"},
+				{8, "f"}, // too late
+				{25, "g"},
+			})
+			windows, errptr = EventTimeWindows(ctx, events, 10*time.Second, 10*time.Second, testEvent.time, 2*time.Second, clock)
+			got             = slices.Collect(windows)
+		)
+		if *errptr != nil {
+			t.Fatal(*errptr)
+		}
+
+		want := [][]string{{"a", "b", "d"}, {"c", "e"}, {"g"}}
+		if names := eventNames(got); !reflect.DeepEqual(names, want) {
+			t.Errorf("got %v, want %v", names, want)
+		}
+		for i, w := range got {
+			wantStart := t0.Add(time.Duration(10*i) * time.Second)
+			if !w.Start.Equal(wantStart) || !w.End.Equal(wantStart.Add(10*time.Second)) {
+				t.Errorf("window %d is [%s, %s), want start %s", i, w.Start, w.End, wantStart)
+			}
+		}
+	})
+
+	t.Run("sliding", func(t *testing.T) {
+		var (
+			clock           = &fakeClock{now: t0}
+			events          = slices.Values([]testEvent{{1, "a"}, {7, "b"}, {12, "c"}})
+			windows, errptr = EventTimeWindows(ctx, events, 10*time.Second, 5*time.Second, testEvent.time, 0, clock)
+			got             = slices.Collect(windows)
+		)
+		if *errptr != nil {
+			t.Fatal(*errptr)
+		}
+
+		want := [][]string{{"a"}, {"a", "b"}, {"b", "c"}, {"c"}}
+		if names := eventNames(got); !reflect.DeepEqual(names, want) {
+			t.Errorf("got %v, want %v", names, want)
+		}
+		if len(got) > 0 && !got[0].Start.Equal(t0.Add(-5*time.Second)) {
+			t.Errorf("first window starts at %s, want %s", got[0].Start, t0.Add(-5*time.Second))
+		}
+	})
+
+	t.Run("idle", func(t *testing.T) {
+		var (
+			clock = &fakeClock{now: t0}
+			idle  = make(chan struct{})
+			wake  = make(chan struct{})
+		)
+
+		events := func(yield func(testEvent) bool) {
+			if !yield(testEvent{1, "a"}) {
+				return
+			}
+			close(idle)
+			<-wake
+		}
+
+		windows, _ := EventTimeWindows(ctx, events, 10*time.Second, 10*time.Second, testEvent.time, 0, clock)
+		out := ToChan(windows)
+		defer close(wake)
+
+		<-idle
+		clock.Wait(1)
+		clock.Advance(9 * time.Second)
+
+		w, ok := <-out
+		if !ok {
+			t.Fatal("no window")
+		}
+		if names := eventNames([]TimeWindow[testEvent]{w}); !reflect.DeepEqual(names, [][]string{{"a"}}) {
+			t.Errorf("got %v, want [[a]]", names)
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+
+		events := func(yield func(testEvent) bool) {
+			yield(testEvent{1, "a"})
+			cancel()
+			<-ctx.Done()
+		}
+
+		windows, errptr := EventTimeWindows(ctx, events, 10*time.Second, 10*time.Second, testEvent.time, 0, &fakeClock{now: t0})
+		_ = Drain(windows)
+		if !errors.Is(*errptr, context.Canceled) {
+			t.Errorf("got error %v, want %v", *errptr, context.Canceled)
+		}
+	})
+}
@@ -0,0 +1,147 @@
+package seqs
+
+import (
+	"cmp"
+	"container/heap"
+	"iter"
+	"slices"
+)
+
+// TopK returns the k largest elements of inp,
+// sorted from largest to smallest.
+// If inp has fewer than k elements, TopK returns all of them.
+//
+// TopK keeps only k elements in memory at a time.
+// It consumes the entire input sequence.
+// Beware of infinite input!
+func TopK[T cmp.Ordered](inp iter.Seq[T], k int) []T {
+	return TopKFunc(inp, k, cmp.Compare[T])
+}
+
+// TopKFunc is like [TopK], but it uses a custom comparison function.
+// The function cmp must return a negative value if its first argument is less than its second,
+// a positive value if it is greater,
+// and zero if they are equal.
+func TopKFunc[T any](inp iter.Seq[T], k int, cmp func(T, T) int) []T {
+	h := newTopKHeap(k, cmp)
+	for val := range inp {
+		h.add(val)
+	}
+	return h.sorted()
+}
+
+// BottomK returns the k smallest elements of inp,
+// sorted from smallest to largest.
+// If inp has fewer than k elements, BottomK returns all of them.
+//
+// BottomK keeps only k elements in memory at a time.
+// It consumes the entire input sequence.
+// Beware of infinite input!
+func BottomK[T cmp.Ordered](inp iter.Seq[T], k int) []T {
+	return BottomKFunc(inp, k, cmp.Compare[T])
+}
+
+// BottomKFunc is like [BottomK], but it uses a custom comparison function.
+// The function cmp must return a negative value if its first argument is less than its second,
+// a positive value if it is greater,
+// and zero if they are equal.
+func BottomKFunc[T any](inp iter.Seq[T], k int, cmp func(T, T) int) []T {
+	return TopKFunc(inp, k, func(a, b T) int { return cmp(b, a) })
+}
+
+// TopK2 returns the k pairs of inp with the largest scores,
+// where the first element of each pair is its score.
+// The result is sorted from largest score to smallest.
+// If inp has fewer than k pairs, TopK2 returns all of them.
+//
+// TopK2 keeps only k pairs in memory at a time.
+// It consumes the entire input sequence.
+// Beware of infinite input!
+func TopK2[K cmp.Ordered, V any](inp iter.Seq2[K, V], k int) []Pair[K, V] {
+	return TopKFunc(ToPairs(inp), k, func(a, b Pair[K, V]) int {
+		return cmp.Compare(a.X, b.X)
+	})
+}
+
+// RunningTopK produces an iterator over snapshots of the k largest elements of inp seen so far,
+// as with [TopK].
+// A snapshot is produced after every n elements of inp,
+// and after the last one
+// (unless that coincides with a snapshot already produced).
+// Each snapshot is a newly allocated slice that the caller may retain.
+//
+// If n is less than 1, only the final snapshot is produced.
+func RunningTopK[T cmp.Ordered](inp iter.Seq[T], k, n int) iter.Seq[[]T] {
+	return RunningTopKFunc(inp, k, n, cmp.Compare[T])
+}
+
+// RunningTopKFunc is like [RunningTopK], but it uses a custom comparison function,
+// as with [TopKFunc].
+func RunningTopKFunc[T any](inp iter.Seq[T], k, n int, cmp func(T, T) int) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		var (
+			h     = newTopKHeap(k, cmp)
+			count int
+		)
+
+		for val := range inp {
+			h.add(val)
+			count++
+			if n > 0 && count%n == 0 {
+				if !yield(h.sorted()) {
+					return
+				}
+			}
+		}
+
+		if n < 1 || count%n != 0 {
+			yield(h.sorted())
+		}
+	}
+}
+
+// topKHeap is a min-heap holding the k largest elements seen so far.
+// It implements [heap.Interface].
+type topKHeap[T any] struct {
+	items []T
+	k     int
+	cmp   func(T, T) int
+}
+
+func newTopKHeap[T any](k int, cmp func(T, T) int) *topKHeap[T] {
+	return &topKHeap[T]{
+		items: make([]T, 0, max(k, 0)),
+		k:     k,
+		cmp:   cmp,
+	}
+}
+
+func (h *topKHeap[T]) add(val T) {
+	if len(h.items) < h.k {
+		heap.Push(h, val)
+		return
+	}
+	if h.k > 0 && h.cmp(val, h.items[0]) > 0 {
+		h.items[0] = val
+		heap.Fix(h, 0)
+	}
+}
+
+// sorted returns a copy of the elements of h, from largest to smallest.
+func (h *topKHeap[T]) sorted() []T {
+	result := slices.Clone(h.items)
+	slices.SortFunc(result, func(a, b T) int { return h.cmp(b, a) })
+	return result
+}
+
+func (h *topKHeap[T]) Len() int           { return len(h.items) }
+func (h *topKHeap[T]) Less(i, j int) bool { return h.cmp(h.items[i], h.items[j]) < 0 }
+func (h *topKHeap[T]) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *topKHeap[T]) Push(x any)         { h.items = append(h.items, x.(T)) }
+
+func (h *topKHeap[T]) Pop() any {
+	n := len(h.items)
+	item := h.items[n-1]
+	h.items = h.items[:n-1]
+	return item
+}
@@ -0,0 +1,67 @@
+package seqs
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestTopK(t *testing.T) {
+	var (
+		rng = rand.New(rand.NewPCG(1, 2))
+		inp = make([]int, 1000)
+	)
+	for i := range inp {
+		inp[i] = rng.IntN(10000)
+	}
+
+	sorted := slices.Sorted(slices.Values(inp))
+
+	for _, k := range []int{0, 1, 10, 1000, 2000} {
+		var (
+			top    = TopK(slices.Values(inp), k)
+			bottom = BottomK(slices.Values(inp), k)
+
+			n          = min(k, len(inp))
+			wantBottom = sorted[:n]
+			wantTop    = slices.Clone(sorted[len(sorted)-n:])
+		)
+		slices.Reverse(wantTop)
+
+		if !slices.Equal(top, wantTop) {
+			t.Errorf("TopK(%d): got %v, want %v", k, top, wantTop)
+		}
+		if !slices.Equal(bottom, wantBottom) {
+			t.Errorf("BottomK(%d): got %v, want %v", k, bottom, wantBottom)
+		}
+	}
+}
+
+func TestTopK2(t *testing.T) {
+	var (
+		scores = FromPairs(slices.Values([]Pair[float64, string]{{0.5, "a"}, {0.9, "b"}, {0.1, "c"}, {0.7, "d"}}))
+		got    = TopK2(scores, 2)
+		want   = []Pair[float64, string]{{0.9, "b"}, {0.7, "d"}}
+	)
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestRunningTopK(t *testing.T) {
+	var (
+		inp  = slices.Values([]int{5, 1, 9, 3, 7, 8, 2})
+		got  = slices.Collect(RunningTopK(inp, 2, 3))
+		want = [][]int{{9, 5}, {9, 8}, {9, 8}}
+	)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	got = slices.Collect(RunningTopK(Limit(inp, 6), 2, 3))
+	want = [][]int{{9, 5}, {9, 8}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
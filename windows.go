@@ -0,0 +1,79 @@
+package seqs
+
+import "iter"
+
+// Windows produces an iterator over windows of consecutive elements of inp.
+// Each window is a slice of size elements,
+// and each window begins step elements after the previous one.
+// If step is less than size, the windows overlap ("sliding" windows).
+// If step equals size, they are adjacent ("tumbling" windows, as with [Pages]).
+// If step is greater than size, some elements are skipped.
+//
+// For example, with size 3 and step 1,
+// the input [1, 2, 3, 4, 5] produces [1, 2, 3], [2, 3, 4], [3, 4, 5].
+//
+// If partial is true, then after the input ends,
+// Windows also produces any windows that began but did not fill up,
+// each containing fewer than size elements.
+// In the example above, those are [4, 5] and [5].
+//
+// If size or step is less than 1, the output is empty.
+//
+// The elements are held in a ring buffer of size elements,
+// and each window is copied out of it into a slice that is reused from one window to the next.
+// So the caller must not modify a yielded slice,
+// nor retain it beyond the current step of the iteration.
+// Use [slices.Clone] to keep a copy.
+func Windows[T any](inp iter.Seq[T], size, step int, partial bool) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		if size < 1 || step < 1 {
+			return
+		}
+
+		var (
+			buf = make([]T, size) // element number i is in buf[i%size]
+			out = make([]T, 0, size)
+			n   int
+		)
+
+		// Emit the window containing elements start through end-1.
+		emit := func(start, end int) bool {
+			out = out[:0]
+			for i := start; i < end; i++ {
+				out = append(out, buf[i%size])
+			}
+			return yield(out)
+		}
+
+		for val := range inp {
+			buf[n%size] = val
+			n++
+			if start := n - size; start >= 0 && start%step == 0 {
+				if !emit(start, n) {
+					return
+				}
+			}
+		}
+
+		if !partial {
+			return
+		}
+
+		// The first window that did not fill up.
+		start := 0
+		if n >= size {
+			start = ((n-size)/step + 1) * step
+		}
+		for ; start < n; start += step {
+			if !emit(start, n) {
+				return
+			}
+		}
+	}
+}
+
+// Windows2 is like [Windows] but operates on a sequence of pairs.
+// The same caveat applies about not modifying or retaining the yielded slices.
+func Windows2[T, U any](inp iter.Seq2[T, U], size, step int, partial bool) iter.Seq[[]Pair[T, U]] {
+	return Windows(ToPairs(inp), size, step, partial)
+}
@@ -0,0 +1,87 @@
+package seqs
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestWindows(t *testing.T) {
+	cases := []struct {
+		n, size, step int
+		partial       bool
+		want          [][]int
+	}{{
+		n: 5, size: 3, step: 1,
+		want: [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}},
+	}, {
+		n: 5, size: 3, step: 1, partial: true,
+		want: [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}, {4, 5}, {5}},
+	}, {
+		n: 7, size: 3, step: 3,
+		want: [][]int{{1, 2, 3}, {4, 5, 6}},
+	}, {
+		n: 7, size: 3, step: 3, partial: true,
+		want: [][]int{{1, 2, 3}, {4, 5, 6}, {7}},
+	}, {
+		n: 6, size: 3, step: 3, partial: true,
+		want: [][]int{{1, 2, 3}, {4, 5, 6}},
+	}, {
+		n: 8, size: 4, step: 2, partial: true,
+		want: [][]int{{1, 2, 3, 4}, {3, 4, 5, 6}, {5, 6, 7, 8}, {7, 8}},
+	}, {
+		n: 9, size: 2, step: 3, partial: true,
+		want: [][]int{{1, 2}, {4, 5}, {7, 8}},
+	}, {
+		n: 8, size: 2, step: 3, partial: true,
+		want: [][]int{{1, 2}, {4, 5}, {7, 8}},
+	}, {
+		n: 7, size: 2, step: 3, partial: true,
+		want: [][]int{{1, 2}, {4, 5}, {7}},
+	}, {
+		n: 3, size: 3, step: 1, partial: true,
+		want: [][]int{{1, 2, 3}, {2, 3}, {3}},
+	}, {
+		n: 3, size: 3, step: 3, partial: true,
+		want: [][]int{{1, 2, 3}},
+	}, {
+		n: 2, size: 3, step: 1,
+	}, {
+		n: 2, size: 3, step: 1, partial: true,
+		want: [][]int{{1, 2}, {2}},
+	}, {
+		n: 0, size: 3, step: 1, partial: true,
+	}, {
+		n: 5, size: 0, step: 1,
+	}}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("n=%d,size=%d,step=%d,partial=%t", tc.n, tc.size, tc.step, tc.partial), func(t *testing.T) {
+			var (
+				inp = Limit(Ints(1, 1), tc.n)
+				got [][]int
+			)
+			for w := range Windows(inp, tc.size, tc.step, tc.partial) {
+				got = append(got, slices.Clone(w))
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWindows2(t *testing.T) {
+	var (
+		inp = slices.All([]string{"a", "b", "c"})
+		got [][]Pair[int, string]
+	)
+	for w := range Windows2(inp, 2, 1, false) {
+		got = append(got, slices.Clone(w))
+	}
+	want := [][]Pair[int, string]{{{0, "a"}, {1, "b"}}, {{1, "b"}, {2, "c"}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}